@@ -27,6 +27,7 @@ func main() {
 	// Get information about the dictionary
 	log.Printf("Number of words in Alice In Wonderlands: %v", dict.WordCount)
 	log.Printf("Number of unique words in Alice In Wonderlands: %v", dict.UniqueWordCount)
+	log.Printf("Statistics of the dictionary:\n%v", dict.Stats())
 
 	// Get information about rabbit
 	wordInformation := dict.Get("rabbit")
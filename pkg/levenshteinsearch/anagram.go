@@ -0,0 +1,183 @@
+package levenshteinsearch
+
+import (
+	"sort"
+)
+
+// AnagramWildcard is the rune standing for any rune in the letters of an anagram search
+const AnagramWildcard = '?'
+
+// anagramIndex files the words of a dictionary under the sorted list of their runes, so that all the
+// anagrams of a word share the same key
+type anagramIndex struct {
+	wordsByKey map[string][]string
+	// runeCounts gives, for each rune, the number of words using it
+	runeCounts map[rune]int
+}
+
+// addWord files a new word under its key
+func (index *anagramIndex) addWord(word string) {
+	key := getAnagramKey(word)
+	index.wordsByKey[key] = append(index.wordsByKey[key], word)
+	for _, r := range getDistinctRunes(key) {
+		index.runeCounts[r]++
+	}
+}
+
+// removeWord removes a word from its key
+func (index *anagramIndex) removeWord(word string) {
+	key := getAnagramKey(word)
+	words := index.wordsByKey[key]
+	for i, candidate := range words {
+		if candidate == word {
+			words = append(words[:i], words[i+1:]...)
+			break
+		}
+	}
+	if len(words) == 0 {
+		delete(index.wordsByKey, key)
+	} else {
+		index.wordsByKey[key] = words
+	}
+
+	for _, r := range getDistinctRunes(key) {
+		index.runeCounts[r]--
+		if index.runeCounts[r] == 0 {
+			delete(index.runeCounts, r)
+		}
+	}
+}
+
+// getWords returns the words made of the letters of the key plus one rune of the index for each wildcard.
+// The runes replacing the wildcards are taken in increasing order, starting at first, so that each set of
+// runes is only tried once
+func (index *anagramIndex) getWords(key []rune, wildcards int, first rune, visit func(word string)) {
+	if wildcards == 0 {
+		sorted := sortRunes(append([]rune(nil), key...))
+		for _, word := range index.wordsByKey[string(sorted)] {
+			visit(word)
+		}
+		return
+	}
+
+	for r := range index.runeCounts {
+		if r >= first {
+			extended := append(append(make([]rune, 0, len(key)+1), key...), r)
+			index.getWords(extended, wildcards-1, r, visit)
+		}
+	}
+}
+
+// EnableAnagramIndex files all the words of the dictionary under the sorted list of their runes, which
+// makes SearchAnagrams a direct lookup. The words put afterwards are also filed.
+func (dictionary *Dictionary) EnableAnagramIndex() {
+	index := &anagramIndex{
+		wordsByKey: make(map[string][]string),
+		runeCounts: make(map[rune]int),
+	}
+
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		index.addWord(word)
+	})
+
+	dictionary.anagram = index
+}
+
+// SearchAnagrams returns all the words of the dictionary using exactly the given letters. Each
+// AnagramWildcard in the letters stands for any rune. The words are looked up in the anagram index if it is
+// enabled, otherwise the trie is walked.
+func (dictionary *Dictionary) SearchAnagrams(letters string) map[string]*WordInformation {
+	results := map[string]*WordInformation{}
+
+	available, wildcards := getAvailableLetters(letters)
+	if dictionary.anagram != nil {
+		key := make([]rune, 0, len(letters))
+		for r, count := range available {
+			for i := 0; i < count; i++ {
+				key = append(key, r)
+			}
+		}
+		dictionary.anagram.getWords(key, wildcards, 0, func(word string) {
+			results[word] = dictionary.Get(word)
+		})
+		return results
+	}
+
+	length := len([]rune(letters))
+	dictionary.Root.searchLetters("", 0, available, wildcards, func(word string, information *WordInformation, wordLength int) {
+		if wordLength == length {
+			results[word] = information
+		}
+	})
+	return results
+}
+
+// SearchSubAnagrams returns all the words of the dictionary that can be formed with the given letters, each
+// letter being used at most once. Each AnagramWildcard in the letters stands for any rune. The trie is walked
+// with the letters still available, so that a branch is cut as soon as its rune is missing.
+func (dictionary *Dictionary) SearchSubAnagrams(letters string) map[string]*WordInformation {
+	results := map[string]*WordInformation{}
+
+	available, wildcards := getAvailableLetters(letters)
+	dictionary.Root.searchLetters("", 0, available, wildcards, func(word string, information *WordInformation, wordLength int) {
+		results[word] = information
+	})
+	return results
+}
+
+// searchLetters calls visit for each word below the node that can be formed with the available letters
+// and wildcards
+func (trie *RuneTrie) searchLetters(prefix string, depth int, available map[rune]int, wildcards int, visit func(word string, information *WordInformation, wordLength int)) {
+	if trie.information != nil {
+		visit(prefix, trie.information, depth)
+	}
+
+	for character, child := range trie.children {
+		// A wildcard is only used for a missing rune
+		if available[character] > 0 {
+			available[character]--
+			child.searchLetters(prefix+string(character), depth+1, available, wildcards, visit)
+			available[character]++
+		} else if wildcards > 0 {
+			child.searchLetters(prefix+string(character), depth+1, available, wildcards-1, visit)
+		}
+	}
+}
+
+// getAvailableLetters returns the number of each rune of the letters, and the number of wildcards
+func getAvailableLetters(letters string) (map[rune]int, int) {
+	available := make(map[rune]int)
+	wildcards := 0
+	for _, r := range letters {
+		if r == AnagramWildcard {
+			wildcards++
+		} else {
+			available[r]++
+		}
+	}
+	return available, wildcards
+}
+
+// getAnagramKey returns the runes of the word sorted, which is the same for all its anagrams
+func getAnagramKey(word string) string {
+	return string(sortRunes([]rune(word)))
+}
+
+// sortRunes sorts the runes in place and returns them
+func sortRunes(runes []rune) []rune {
+	sort.Slice(runes, func(i, j int) bool {
+		return runes[i] < runes[j]
+	})
+	return runes
+}
+
+// getDistinctRunes returns the distinct runes of a key, whose runes are sorted
+func getDistinctRunes(key string) []rune {
+	distinct := make([]rune, 0, len(key))
+	for _, r := range key {
+		if len(distinct) == 0 || distinct[len(distinct)-1] != r {
+			distinct = append(distinct, r)
+		}
+	}
+	return distinct
+}
@@ -0,0 +1,111 @@
+package levenshteinsearch
+
+import (
+	"testing"
+)
+
+func TestSearchAnagrams(t *testing.T) {
+
+	for _, indexed := range []bool{true, false} {
+		dict := CreateDictionary()
+		dict.Put("listen")
+		if indexed {
+			dict.EnableAnagramIndex()
+		}
+		dict.Put("silent")
+		dict.Put("enlist")
+		dict.Put("tinsel")
+		dict.Put("list")
+		dict.Put("listens")
+
+		results := dict.SearchAnagrams("nestil")
+		if len(results) != 4 || results["listen"] == nil || results["silent"] == nil || results["enlist"] == nil || results["tinsel"] == nil {
+			t.Errorf("Unexpected anagrams (indexed: %v): %v", indexed, results)
+		}
+
+		// The wildcard stands for the s of listens
+		results = dict.SearchAnagrams("nestil?")
+		if len(results) != 1 || results["listens"] == nil {
+			t.Errorf("Unexpected anagrams with a wildcard (indexed: %v): %v", indexed, results)
+		}
+
+		results = dict.SearchAnagrams("t??s")
+		if len(results) != 1 || results["list"] == nil {
+			t.Errorf("Unexpected anagrams with two wildcards (indexed: %v): %v", indexed, results)
+		}
+
+		dict.Remove("silent")
+		results = dict.SearchAnagrams("nestil")
+		if len(results) != 3 || results["silent"] != nil {
+			t.Errorf("Unexpected anagrams after the removal (indexed: %v): %v", indexed, results)
+		}
+	}
+}
+
+func TestSearchSubAnagrams(t *testing.T) {
+
+	dict := CreateDictionary()
+	for _, word := range []string{"a", "at", "tat", "eat", "tea", "teat", "treat", "rat"} {
+		dict.Put(word)
+	}
+
+	results := dict.SearchSubAnagrams("tea")
+	if len(results) != 4 || results["a"] == nil || results["at"] == nil || results["eat"] == nil || results["tea"] == nil {
+		t.Errorf("Unexpected sub-anagrams: %v", results)
+	}
+
+	// The letters are used only once, but the wildcard gives the second t or the r
+	results = dict.SearchSubAnagrams("tea?")
+	if len(results) != 7 || results["tat"] == nil || results["teat"] == nil || results["rat"] == nil {
+		t.Errorf("Unexpected sub-anagrams with a wildcard: %v", results)
+	}
+}
+
+func TestSearchSubAnagramsMatchesBruteForce(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	indexed := CreateDictionary()
+	indexed.EnableAnagramIndex()
+	for _, word := range aliceWords {
+		indexed.Put(word)
+	}
+
+	data := dict.getAllWords()
+	for _, letters := range []string{"rabbit", "alice", "theqn", "sentil?", "a?e"} {
+		available, wildcards := getAvailableLetters(letters)
+
+		expectedSub := map[string]bool{}
+		expectedExact := map[string]bool{}
+		for word := range data {
+			missing := 0
+			remaining := map[rune]int{}
+			for r, count := range available {
+				remaining[r] = count
+			}
+			for _, r := range word {
+				if remaining[r] > 0 {
+					remaining[r]--
+				} else {
+					missing++
+				}
+			}
+			if missing <= wildcards {
+				expectedSub[word] = true
+				if len([]rune(word)) == len([]rune(letters)) {
+					expectedExact[word] = true
+				}
+			}
+		}
+
+		compareResults(t, "Sub-anagram", letters, 0, expectedSub, dict.SearchSubAnagrams(letters))
+		compareResults(t, "Anagram", letters, 0, expectedExact, dict.SearchAnagrams(letters))
+		compareResults(t, "Indexed anagram", letters, 0, expectedExact, indexed.SearchAnagrams(letters))
+	}
+}
@@ -5,6 +5,24 @@ import (
 	"sort"
 )
 
+// State is an opaque state of an Automaton. Each implementation of Automaton defines its own states
+// and only expects to receive back the states it has generated itself.
+type State interface{}
+
+// Automaton is the definition of a matcher that can be walked rune by rune along the trie. As long as
+// CanMatch returns true for a state, the walk continues with the children of the current node. Otherwise
+// the full branch is skipped.
+type Automaton interface {
+	// Start gives the initial state allowing to step into the automaton
+	Start() State
+	// Step generates the next state based on the current one + the given char
+	Step(state State, character rune) State
+	// IsMatch returns true if the given state is matching
+	IsMatch(state State) bool
+	// CanMatch returns true if the given state, or any state following it, can match
+	CanMatch(state State) bool
+}
+
 // LevenshteinAutomaton is simply the definition of the automaton.
 type LevenshteinAutomaton struct {
 	distanceMax       int
@@ -62,7 +80,7 @@ func CreateAutomaton(searchedTerm string, distanceMax int) *LevenshteinAutomaton
 }
 
 // Start gives the initial state allowing to step into the automaton
-func (automaton *LevenshteinAutomaton) Start() AutomatonState {
+func (automaton *LevenshteinAutomaton) Start() State {
 	indices := make([]int, automaton.distanceMax+1)
 	for i := 0; i < automaton.distanceMax+1; i++ {
 		indices[i] = i
@@ -88,7 +106,9 @@ func min(a int, b int) int {
 
 // Step steps through the automaton by generating the next state based on the current one + the given
 // char.
-func (automaton *LevenshteinAutomaton) Step(state AutomatonState, character rune) AutomatonState {
+func (automaton *LevenshteinAutomaton) Step(genericState State, character rune) State {
+	state := genericState.(AutomatonState)
+
 	var newIndices []int
 	var newValues []int
 
@@ -133,15 +153,31 @@ func (automaton *LevenshteinAutomaton) Step(state AutomatonState, character rune
 }
 
 // IsMatch returns true if the given states is matching
-func (automaton *LevenshteinAutomaton) IsMatch(state AutomatonState) bool {
+func (automaton *LevenshteinAutomaton) IsMatch(genericState State) bool {
+	state := genericState.(AutomatonState)
 	return (len(state.indices) > 0) && (state.indices[len(state.indices)-1] == len(automaton.searchedTermRunes))
 }
 
 // CanMatch returns true if the given states can match
-func (automaton *LevenshteinAutomaton) CanMatch(state AutomatonState) bool {
+func (automaton *LevenshteinAutomaton) CanMatch(genericState State) bool {
+	state := genericState.(AutomatonState)
 	return len(state.indices) > 0
 }
 
+// distance returns the Levenshtein distance of a matching state, or -1 if the state is not matching
+func (automaton *LevenshteinAutomaton) distance(state AutomatonState) int {
+	if !automaton.IsMatch(state) {
+		return -1
+	}
+	return state.values[len(state.values)-1]
+}
+
+// getCost returns the cost and the number of edits of a matching state, both being the Levenshtein distance
+func (automaton *LevenshteinAutomaton) getCost(state State) (float64, int) {
+	distance := automaton.distance(state.(AutomatonState))
+	return float64(distance), distance
+}
+
 // digraphInformation is a structure filled during the recursive walk of the generated digraph. It holds
 // together the information of the digraph
 type digraphInformation struct {
@@ -169,7 +205,7 @@ func CreateDigraph(searchedTerm string, distanceMax int) []string {
 		matching:    make([]int, 0),
 	}
 
-	automaton.explore(digraphInfo, automaton.Start())
+	automaton.explore(digraphInfo, automaton.Start().(AutomatonState))
 
 	// Sort the transition for a "more" humanly readable output
 	sort.Slice(digraphInfo.transitions, func(i, j int) bool {
@@ -219,7 +255,7 @@ func (automaton *LevenshteinAutomaton) explore(digraphInfo *digraphInformation,
 
 	// The recursively explore the new found state
 	for _, c := range automaton.getAllTransitions(state) {
-		newState := automaton.Step(state, c)
+		newState := automaton.Step(state, c).(AutomatonState)
 		nextStateId := automaton.explore(digraphInfo, newState)
 		digraphInfo.transitions = append(digraphInfo.transitions, transitionDetail{
 			from:      stateId,
@@ -251,7 +287,7 @@ func (automaton *LevenshteinAutomaton) getAllTransitions(state AutomatonState) [
 	}
 
 	// Append the generic '*' transition
-	transitions = append(automaton.getAllTransitions(state), '*')
+	transitions = append(transitions, '*')
 
 	// Luxury, sort the transitions to ensure stable results. As generated digraph
 	// are not really useful for "production" this sort should not have any real life
@@ -0,0 +1,97 @@
+package levenshteinsearch
+
+// sentenceBoundary is the word standing for the beginning and the end of a sentence in the bigrams
+const sentenceBoundary = ""
+
+// BigramModel records the counts of the pairs of consecutive words of a corpus, along with the counts of the
+// words themselves, which are stored in a regular Dictionary. The words are put one by one, as for the
+// dictionary, the model remembering the previous word until the end of the sentence.
+type BigramModel struct {
+	// Dictionary holds the words put in the model and their counts
+	Dictionary *Dictionary
+	// BigramCount is the total number of pairs recorded
+	BigramCount int
+
+	counts         map[string]map[string]int
+	previousCounts map[string]int
+	previous       string
+}
+
+// CreateBigramModel creates a new model, storing the words in the given dictionary. If the dictionary is nil,
+// a new one is created.
+func CreateBigramModel(dictionary *Dictionary) *BigramModel {
+	if dictionary == nil {
+		dictionary = CreateDictionary()
+	}
+
+	return &BigramModel{
+		Dictionary:     dictionary,
+		BigramCount:    0,
+		counts:         make(map[string]map[string]int),
+		previousCounts: make(map[string]int),
+		previous:       sentenceBoundary,
+	}
+}
+
+// Put adds the word to the dictionary and records the pair it forms with the previous word of the
+// sentence. It returns true if the word is new in the dictionary.
+func (model *BigramModel) Put(word string) bool {
+	model.addPair(model.previous, word)
+	model.previous = word
+
+	return model.Dictionary.Put(word)
+}
+
+// EndSentence ends the current sentence, so that the next word put is not paired with the previous one. The
+// last word of the sentence is recorded as followed by the end of the sentence.
+func (model *BigramModel) EndSentence() {
+	if model.previous != sentenceBoundary {
+		model.addPair(model.previous, sentenceBoundary)
+	}
+	model.previous = sentenceBoundary
+}
+
+// addPair records a pair of consecutive words
+func (model *BigramModel) addPair(previous string, word string) {
+	followers := model.counts[previous]
+	if followers == nil {
+		followers = make(map[string]int)
+		model.counts[previous] = followers
+	}
+	followers[word]++
+	model.previousCounts[previous]++
+	model.BigramCount++
+}
+
+// PutSentence adds all the words of a sentence, then ends it
+func (model *BigramModel) PutSentence(words []string) {
+	for _, word := range words {
+		model.Put(word)
+	}
+	model.EndSentence()
+}
+
+// GetCount returns the number of times the word was found right after the previous one. An empty previous
+// word stands for the beginning of a sentence, and an empty word for its end.
+func (model *BigramModel) GetCount(previous string, word string) int {
+	return model.counts[previous][word]
+}
+
+// GetProbability returns the probability of the word following the previous one. The probability of the
+// pair is interpolated with the probability of the word alone, with add-one smoothing, so that the unseen
+// pairs and words keep a small probability. bigramWeight, between 0 and 1, is the weight of the pair.
+func (model *BigramModel) GetProbability(previous string, word string, bigramWeight float64) float64 {
+	count := 0
+	if information := model.Dictionary.Get(word); information != nil {
+		count = information.Count
+	}
+	unigram := float64(count+1) / float64(model.Dictionary.WordCount+model.Dictionary.UniqueWordCount+1)
+
+	previousCount := model.previousCounts[previous]
+	if previousCount == 0 {
+		return unigram
+	}
+	bigram := float64(model.counts[previous][word]) / float64(previousCount)
+
+	return bigramWeight*bigram + (1-bigramWeight)*unigram
+}
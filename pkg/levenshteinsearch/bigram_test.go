@@ -0,0 +1,53 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestBigramModelCounts(t *testing.T) {
+
+	model := CreateBigramModel(nil)
+	model.PutSentence([]string{"the", "white", "rabbit"})
+	model.PutSentence([]string{"the", "white", "queen"})
+	model.Put("the")
+	model.Put("rabbit")
+
+	if model.Dictionary.WordCount != 8 || model.Dictionary.UniqueWordCount != 4 || model.BigramCount != 10 {
+		t.Errorf("Unexpected counts: %v words, %v unique words, %v bigrams", model.Dictionary.WordCount, model.Dictionary.UniqueWordCount, model.BigramCount)
+	}
+	if model.GetCount("the", "white") != 2 || model.GetCount("the", "rabbit") != 1 || model.GetCount("white", "the") != 0 {
+		t.Error("Unexpected bigram counts")
+	}
+	if model.GetCount("", "the") != 3 {
+		t.Error("Expected the beginnings of sentence to be counted")
+	}
+	if model.GetCount("rabbit", "") != 1 || model.GetCount("queen", "") != 1 {
+		t.Error("Expected the ends of sentence to be counted")
+	}
+	if model.GetCount("queen", "the") != 0 {
+		t.Error("Expected the sentences to not be paired")
+	}
+}
+
+func TestBigramModelProbability(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("alice")
+	model := CreateBigramModel(dict)
+	model.PutSentence([]string{"the", "white", "rabbit"})
+
+	if model.Dictionary != dict || dict.WordCount != 4 {
+		t.Error("Expected the words to be put in the given dictionary")
+	}
+
+	if model.GetProbability("the", "white", 1) != 1 {
+		t.Error("Expected 'white' to always follow 'the'")
+	}
+	if model.GetProbability("the", "rabbit", 0.8) >= model.GetProbability("the", "white", 0.8) {
+		t.Error("Expected a seen pair to be more probable than an unseen one")
+	}
+	if probability := model.GetProbability("the", "queen", 0.8); probability <= 0 {
+		t.Errorf("Expected an unknown word to keep a small probability, found %v", probability)
+	}
+	if model.GetProbability("alice", "rabbit", 0.8) != model.GetProbability("queen", "rabbit", 0.8) {
+		t.Error("Expected the probability after a word never followed to be the one of the word alone")
+	}
+}
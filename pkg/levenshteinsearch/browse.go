@@ -0,0 +1,89 @@
+package levenshteinsearch
+
+import (
+	"strings"
+)
+
+// WordEntry is a word of the dictionary along with its information
+type WordEntry struct {
+	// Word is the word
+	Word string
+	// Information is the information of the word
+	Information *WordInformation
+}
+
+// Range returns the words greater or equal to from and lower than to, sorted by their runes. An empty to
+// leaves the range open. The cursor after is the last word of the previous page, or "" for the first page.
+// At most limit words are returned, or all of them if limit is 0 or lower.
+func (dictionary *Dictionary) Range(from string, to string, after string, limit int) []WordEntry {
+	entries := make([]WordEntry, 0)
+	dictionary.walkFrom(getPageStart(from, after), func(word string, information *WordInformation) bool {
+		if to != "" && word >= to {
+			return false
+		}
+		entries = append(entries, WordEntry{Word: word, Information: information})
+		return limit <= 0 || len(entries) < limit
+	})
+	return entries
+}
+
+// Prefix returns the words beginning with the prefix, sorted by their runes. The cursor after is the last
+// word of the previous page, or "" for the first page. At most limit words are returned, or all of them if
+// limit is 0 or lower.
+func (dictionary *Dictionary) Prefix(prefix string, after string, limit int) []WordEntry {
+	entries := make([]WordEntry, 0)
+	dictionary.walkFrom(getPageStart(prefix, after), func(word string, information *WordInformation) bool {
+		// The words beginning with the prefix are contiguous
+		if !strings.HasPrefix(word, prefix) {
+			return false
+		}
+		entries = append(entries, WordEntry{Word: word, Information: information})
+		return limit <= 0 || len(entries) < limit
+	})
+	return entries
+}
+
+// getPageStart returns the lowest word of a page: the lowest word of the range, or the first word following
+// the cursor, which is the cursor followed by the lowest rune
+func getPageStart(from string, after string) string {
+	if after != "" && after+"\x00" > from {
+		return after + "\x00"
+	}
+	return from
+}
+
+// walkFrom calls visit for the words greater or equal to from, in increasing order, until visit returns false
+func (dictionary *Dictionary) walkFrom(from string, visit func(word string, information *WordInformation) bool) {
+	dictionary.updateCounts()
+	dictionary.Root.walkFrom("", []rune(from), true, visit)
+}
+
+// walkFrom is the recursive part of the walk. While bounded, the node is on the path of the lower bound and
+// the words before the bound are skipped. It returns false once the walk is stopped.
+func (trie *RuneTrie) walkFrom(prefix string, bound []rune, bounded bool, visit func(word string, information *WordInformation) bool) bool {
+	// The word of the node is lower than the bound if it is a strict prefix of it
+	if trie.information != nil && (!bounded || len(bound) == 0) {
+		if !visit(prefix, trie.information) {
+			return false
+		}
+	}
+
+	for _, character := range trie.characters {
+		childBounded := bounded && len(bound) > 0
+		if childBounded && character < bound[0] {
+			continue
+		}
+
+		var childBound []rune
+		if childBounded && character == bound[0] {
+			childBound = bound[1:]
+		} else {
+			childBounded = false
+		}
+
+		if !trie.children[character].walkFrom(prefix+string(character), childBound, childBounded, visit) {
+			return false
+		}
+	}
+	return true
+}
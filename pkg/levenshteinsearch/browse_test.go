@@ -0,0 +1,155 @@
+package levenshteinsearch
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// getEntryWords returns the words of the entries
+func getEntryWords(entries []WordEntry) []string {
+	words := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		words = append(words, entry.Word)
+	}
+	return words
+}
+
+func TestRange(t *testing.T) {
+
+	dict := CreateDictionary()
+	for _, word := range []string{"mad", "made", "madam", "mar", "march", "ma", "m", "rabbit", "rabbit"} {
+		dict.Put(word)
+	}
+
+	entries := dict.Range("mad", "mar", "", 0)
+	if words := strings.Join(getEntryWords(entries), ","); words != "mad,madam,made" {
+		t.Errorf("Unexpected range: %v", words)
+	}
+
+	// An empty upper bound leaves the range open
+	entries = dict.Range("", "", "", 2)
+	if words := strings.Join(getEntryWords(entries), ","); words != "m,ma" {
+		t.Errorf("Unexpected first page: %v", words)
+	}
+	entries = dict.Range("", "", entries[1].Word, 0)
+	if words := strings.Join(getEntryWords(entries), ","); words != "mad,madam,made,mar,march,rabbit" {
+		t.Errorf("Unexpected second page: %v", words)
+	}
+	if entries[5].Information.Count != 2 {
+		t.Errorf("Expected a count of 2 for rabbit, found %v", entries[5].Information.Count)
+	}
+
+	// The cursor applies within the range
+	entries = dict.Range("mad", "mar", "madam", 0)
+	if words := strings.Join(getEntryWords(entries), ","); words != "made" {
+		t.Errorf("Unexpected page after madam: %v", words)
+	}
+	if entries := dict.Range("mad", "mar", "a", 1); len(entries) != 1 || entries[0].Word != "mad" {
+		t.Errorf("Expected a cursor before the range to be ignored, found %v", getEntryWords(entries))
+	}
+
+	if entries := dict.Range("mb", "r", "", 0); len(entries) != 0 {
+		t.Errorf("Expected no word from mb to r, found %v", getEntryWords(entries))
+	}
+	if entries := dict.Range("mar", "mad", "", 0); len(entries) != 0 {
+		t.Errorf("Expected no word in an empty range, found %v", getEntryWords(entries))
+	}
+}
+
+func TestPrefix(t *testing.T) {
+
+	dict := CreateDictionary()
+	for _, word := range []string{"rab", "rabbit", "rabbits", "rabid", "rat", "ra", "été", "étés"} {
+		dict.Put(word)
+	}
+
+	entries := dict.Prefix("rab", "", 2)
+	if words := strings.Join(getEntryWords(entries), ","); words != "rab,rabbit" {
+		t.Errorf("Unexpected first page: %v", words)
+	}
+	entries = dict.Prefix("rab", entries[1].Word, 2)
+	if words := strings.Join(getEntryWords(entries), ","); words != "rabbits,rabid" {
+		t.Errorf("Unexpected second page: %v", words)
+	}
+	entries = dict.Prefix("rab", entries[1].Word, 2)
+	if len(entries) != 0 {
+		t.Errorf("Expected an empty last page, found %v", getEntryWords(entries))
+	}
+
+	// A cursor before the prefix is ignored
+	entries = dict.Prefix("été", "a", 0)
+	if words := strings.Join(getEntryWords(entries), ","); words != "été,étés" {
+		t.Errorf("Unexpected words: %v", words)
+	}
+
+	if entries := dict.Prefix("x", "", 0); len(entries) != 0 {
+		t.Errorf("Expected no word beginning with x, found %v", getEntryWords(entries))
+	}
+}
+
+func TestPrefixPagesMatchBruteForce(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	words := make([]string, 0, dict.UniqueWordCount)
+	dict.Root.forEachWord("", func(word string, information *WordInformation) {
+		words = append(words, word)
+	})
+	sort.Strings(words)
+
+	for _, prefix := range []string{"", "a", "th", "rabbit", "qu"} {
+		expected := make([]string, 0)
+		for _, word := range words {
+			if strings.HasPrefix(word, prefix) {
+				expected = append(expected, word)
+			}
+		}
+
+		found := make([]string, 0)
+		after := ""
+		for {
+			entries := dict.Prefix(prefix, after, 7)
+			if len(entries) == 0 {
+				break
+			}
+			found = append(found, getEntryWords(entries)...)
+			after = entries[len(entries)-1].Word
+		}
+
+		if strings.Join(found, ",") != strings.Join(expected, ",") {
+			t.Errorf("Unexpected words for the prefix %v: found %v words, expected %v", prefix, len(found), len(expected))
+		}
+	}
+
+	for _, bounds := range [][2]string{{"mad", "mar"}, {"a", "b"}, {"", "c"}, {"the", "thf"}, {"x", ""}} {
+		expected := make([]string, 0)
+		for _, word := range words {
+			if word >= bounds[0] && (bounds[1] == "" || word < bounds[1]) {
+				expected = append(expected, word)
+			}
+		}
+		found := make([]string, 0)
+		after := ""
+		for {
+			entries := dict.Range(bounds[0], bounds[1], after, 7)
+			if len(entries) == 0 {
+				break
+			}
+			found = append(found, getEntryWords(entries)...)
+			after = entries[len(entries)-1].Word
+		}
+		if strings.Join(found, ",") != strings.Join(expected, ",") {
+			t.Errorf("Unexpected words from %v to %v: found %v words, expected %v", bounds[0], bounds[1], len(found), len(expected))
+		}
+		if count := dict.CountRange(bounds[0], bounds[1]); bounds[1] != "" && count != len(expected) {
+			t.Errorf("Expected %v words from %v to %v, counted %v", len(expected), bounds[0], bounds[1], count)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package levenshteinsearch
+
+import (
+	"unicode"
+)
+
+// CaseFoldingAutomaton wraps an automaton so that the runes of the trie are compared with Unicode simple case
+// folding. The wrapped automaton must have been created for the folded searched term, as given by FoldCase.
+type CaseFoldingAutomaton struct {
+	automaton Automaton
+}
+
+// CreateCaseFoldingAutomaton wraps the given automaton, created for a folded searched term
+func CreateCaseFoldingAutomaton(automaton Automaton) *CaseFoldingAutomaton {
+	return &CaseFoldingAutomaton{
+		automaton: automaton,
+	}
+}
+
+// CreateCaseInsensitiveAutomaton creates a new Levenshtein automaton ignoring the case of the runes
+func CreateCaseInsensitiveAutomaton(searchedTerm string, distanceMax int) *CaseFoldingAutomaton {
+	return CreateCaseFoldingAutomaton(CreateAutomaton(FoldCase(searchedTerm), distanceMax))
+}
+
+// Start gives the initial state allowing to step into the automaton
+func (automaton *CaseFoldingAutomaton) Start() State {
+	return automaton.automaton.Start()
+}
+
+// Step steps through the automaton by generating the next state based on the current one + the folded
+// char
+func (automaton *CaseFoldingAutomaton) Step(state State, character rune) State {
+	return automaton.automaton.Step(state, foldRune(character))
+}
+
+// IsMatch returns true if the given states is matching
+func (automaton *CaseFoldingAutomaton) IsMatch(state State) bool {
+	return automaton.automaton.IsMatch(state)
+}
+
+// CanMatch returns true if the given states can match
+func (automaton *CaseFoldingAutomaton) CanMatch(state State) bool {
+	return automaton.automaton.CanMatch(state)
+}
+
+// getCost returns the cost and the number of edits of a matching state, if the wrapped automaton gives it
+func (automaton *CaseFoldingAutomaton) getCost(state State) (float64, int) {
+	if ranking, ok := automaton.automaton.(rankingAutomaton); ok {
+		return ranking.getCost(state)
+	}
+	return 0, 0
+}
+
+// SearchAllCaseInsensitive returns all the words of the dictionary having a Levenshtein distance lower or
+// equal to distanceMax with the searched term, ignoring the case. The words are returned as they were put.
+func (dictionary *Dictionary) SearchAllCaseInsensitive(searchedTerm string, distanceMax int) map[string]*WordInformation {
+	return dictionary.Search(CreateCaseInsensitiveAutomaton(searchedTerm, distanceMax))
+}
+
+// FoldCase returns the string with each rune replaced by the representative of its Unicode simple case
+// folding orbit, so that two strings differing only by the case of their runes give the same result
+func FoldCase(text string) string {
+	return string(foldRunes([]rune(text)))
+}
+
+// foldRunes returns a copy of the runes replaced by their case folding representative
+func foldRunes(runes []rune) []rune {
+	folded := make([]rune, len(runes))
+	for i, r := range runes {
+		folded[i] = foldRune(r)
+	}
+	return folded
+}
+
+// foldRune returns the representative of the case folding orbit of the rune: the lower case of the
+// smallest rune of the orbit. For example, "K", "k" and the Kelvin sign all give "k"
+func foldRune(r rune) rune {
+	smallest := r
+	for other := unicode.SimpleFold(r); other != r; other = unicode.SimpleFold(other) {
+		if other < smallest {
+			smallest = other
+		}
+	}
+	return unicode.ToLower(smallest)
+}
+
+// getFoldedNodes returns the nodes reached from the trie by following the runes of the key, ignoring their
+// case. The nodes are given by the actual prefix leading to them.
+func (trie *RuneTrie) getFoldedNodes(key string) map[string]*RuneTrie {
+	nodes := map[string]*RuneTrie{"": trie}
+	for _, r := range key {
+		folded := foldRune(r)
+		nextNodes := make(map[string]*RuneTrie)
+		for prefix, node := range nodes {
+			for character, child := range node.children {
+				if foldRune(character) == folded {
+					nextNodes[prefix+string(character)] = child
+				}
+			}
+		}
+		nodes = nextNodes
+	}
+	return nodes
+}
+
+// countCaseDifferences returns the number of operations of an edit script matching runes that only differ
+// by their case
+func countCaseDifferences(operations []EditOperation) int {
+	count := 0
+	for _, operation := range operations {
+		// The runes may also be matched as equivalent, such as e and é
+		if operation.Type == EditMatch && operation.QueryRune != operation.WordRune && foldRune(operation.QueryRune) == foldRune(operation.WordRune) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,120 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestFoldCase(t *testing.T) {
+
+	if FoldCase("iPhone") != FoldCase("IPHONE") || FoldCase("NASA") != "nasa" {
+		t.Error("Expected the strings differing by their case to be folded the same way")
+	}
+	// Kelvin sign, long s and capital sharp s
+	if FoldCase("K") != "k" || FoldCase("ſ") != "s" || FoldCase("ẞ") != "ß" {
+		t.Error("Expected the special runes to be folded with their orbit")
+	}
+	if FoldCase("Straße") == FoldCase("Strasse") {
+		t.Error("Expected the simple case folding to keep 'ß'")
+	}
+}
+
+func TestSearchAllCaseInsensitive(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("iPhone")
+	dict.Put("NASA")
+	dict.Put("Alice")
+	dict.Put("alice")
+
+	results := dict.SearchAllCaseInsensitive("iphone", 0)
+	if len(results) != 1 || results["iPhone"] == nil {
+		t.Error("Expected 'iPhone' to be found as it was put")
+	}
+
+	results = dict.SearchAllCaseInsensitive("nasa", 1)
+	if len(results) != 1 || results["NASA"] == nil {
+		t.Error("Expected 'NASA' to be found as it was put")
+	}
+
+	results = dict.SearchAllCaseInsensitive("ALICE", 0)
+	if len(results) != 2 || results["Alice"] == nil || results["alice"] == nil {
+		t.Error("Expected both 'Alice' and 'alice' to be found")
+	}
+
+	if len(dict.SearchAll("iphone", 0)) != 0 {
+		t.Error("Expected the regular search to stay case sensitive")
+	}
+}
+
+func TestSearchWithOptionsCaseInsensitive(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("alice")
+	dict.Put("Alice")
+	dict.Put("ALICE")
+	dict.Put("Alize")
+
+	results := dict.SearchWithOptions("Alice", SearchOptions{
+		DistanceMax:     1,
+		PrefixLength:    1,
+		CaseInsensitive: true,
+		PreferExactCase: true,
+		WithEditScript:  true,
+	})
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, found %v", len(results))
+	}
+	expectedWords := []string{"Alice", "alice", "ALICE", "Alize"}
+	expectedDifferences := []int{0, 1, 4, 0}
+	for i, result := range results {
+		if result.Word != expectedWords[i] || result.CaseDifferences != expectedDifferences[i] {
+			t.Errorf("Expected '%v' with %v case differences at position %v, found '%v' with %v", expectedWords[i], expectedDifferences[i], i, result.Word, result.CaseDifferences)
+		}
+	}
+
+	script := results[1].EditScript
+	if GetEditDistance(script) != 0 || script[0].QueryRune != 'A' || script[0].WordRune != 'a' {
+		t.Errorf("Expected the script of 'alice' to keep the original runes, found %v", script)
+	}
+}
+
+func TestSearchWithOptionsCaseInsensitiveEquivalences(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("Cafe")
+	dict.Put("café")
+	dict.Put("Café")
+
+	results := dict.SearchWithOptions("cafe", SearchOptions{
+		DistanceMax:     0,
+		Equivalences:    CreateLatinEquivalences(0),
+		CaseInsensitive: true,
+		PreferExactCase: true,
+	})
+
+	// The accent is not a case difference
+	expectedWords := []string{"café", "Cafe", "Café"}
+	expectedDifferences := []int{0, 1, 1}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, found %v", results)
+	}
+	for i, result := range results {
+		if result.Word != expectedWords[i] || result.CaseDifferences != expectedDifferences[i] {
+			t.Errorf("Expected '%v' with %v case differences at position %v, found '%v' with %v", expectedWords[i], expectedDifferences[i], i, result.Word, result.CaseDifferences)
+		}
+	}
+}
+
+func TestSearchWithOptionsCaseInsensitiveLayout(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("Rabbit")
+
+	results := dict.SearchWithOptions("RSBBIT", SearchOptions{
+		DistanceMax:     1,
+		Layout:          CreateQwertyLayout(),
+		CaseInsensitive: true,
+	})
+	if len(results) != 1 || results[0].Cost != 0.5 {
+		t.Error("Expected 'Rabbit' to be found for a cost of 0.5")
+	}
+}
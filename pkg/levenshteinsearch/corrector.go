@@ -0,0 +1,138 @@
+package levenshteinsearch
+
+import (
+	"math"
+	"strings"
+)
+
+// SentenceCorrector corrects the words of a sentence using their context. The candidates of each token are
+// the words of the dictionary close to it, and the most probable sequence of candidates is chosen with the
+// Viterbi algorithm, given the bigram model and the distance between each token and its candidate.
+type SentenceCorrector struct {
+	model *BigramModel
+	// DistanceMax is the maximum Levenshtein distance between a token and its candidates
+	DistanceMax int
+	// EditCost is the cost, as the opposite of the logarithm of a probability, of each edit between a token
+	// and its candidate
+	EditCost float64
+	// BigramWeight is the weight, between 0 and 1, of the pairs of words compared to the words alone
+	BigramWeight float64
+}
+
+// TokenCorrection is the correction of a single token of a sentence
+type TokenCorrection struct {
+	// Token is the token as found in the sentence
+	Token string
+	// Word is the word chosen for the token. It is the token itself if no candidate was found
+	Word string
+	// Alternatives are all the candidates considered for the token, sorted by increasing distance
+	Alternatives []SearchResult
+}
+
+// SentenceCorrection is the correction of a full sentence
+type SentenceCorrection struct {
+	// Tokens are the corrections of the successive tokens
+	Tokens []TokenCorrection
+	// Cost is the cost of the chosen sequence of words, as the opposite of the logarithm of its probability
+	Cost float64
+}
+
+// Default parameters of the corrector
+const (
+	defaultCorrectorEditCost     = 4
+	defaultCorrectorBigramWeight = 0.8
+)
+
+// CreateSentenceCorrector creates a new corrector using the bigram model
+func CreateSentenceCorrector(model *BigramModel, distanceMax int) *SentenceCorrector {
+	return &SentenceCorrector{
+		model:        model,
+		DistanceMax:  distanceMax,
+		EditCost:     defaultCorrectorEditCost,
+		BigramWeight: defaultCorrectorBigramWeight,
+	}
+}
+
+// Correct corrects a sentence made of tokens separated by spaces
+func (corrector *SentenceCorrector) Correct(sentence string) SentenceCorrection {
+	return corrector.CorrectTokens(strings.Fields(sentence))
+}
+
+// CorrectTokens corrects a sentence already split into tokens
+func (corrector *SentenceCorrector) CorrectTokens(tokens []string) SentenceCorrection {
+	corrections := make([]TokenCorrection, len(tokens))
+	if len(tokens) == 0 {
+		return SentenceCorrection{Tokens: corrections}
+	}
+
+	// The candidates of each token. A token without candidate is kept as is
+	candidates := make([][]SearchResult, len(tokens))
+	for i, token := range tokens {
+		corrections[i].Token = token
+		corrections[i].Alternatives = corrector.model.Dictionary.SearchWithOptions(token, SearchOptions{DistanceMax: corrector.DistanceMax})
+		candidates[i] = corrections[i].Alternatives
+		if len(candidates[i]) == 0 {
+			candidates[i] = []SearchResult{{Word: token}}
+		}
+	}
+
+	// costs[i][j] is the lowest cost of a sequence ending with the candidate j of the token i, and
+	// origins[i][j] is the candidate of the previous token giving it
+	costs := make([][]float64, len(tokens))
+	origins := make([][]int, len(tokens))
+	for i := range tokens {
+		costs[i] = make([]float64, len(candidates[i]))
+		origins[i] = make([]int, len(candidates[i]))
+		for j, candidate := range candidates[i] {
+			editCost := float64(candidate.Distance) * corrector.EditCost
+			if i == 0 {
+				costs[i][j] = corrector.getCost(sentenceBoundary, candidate.Word) + editCost
+				continue
+			}
+
+			costs[i][j] = math.Inf(1)
+			for k, previous := range candidates[i-1] {
+				cost := costs[i-1][k] + corrector.getCost(previous.Word, candidate.Word) + editCost
+				if cost < costs[i][j] {
+					costs[i][j] = cost
+					origins[i][j] = k
+				}
+			}
+		}
+	}
+
+	// Walk back from the best last candidate, followed by the end of the sentence
+	last := len(tokens) - 1
+	best := 0
+	total := math.Inf(1)
+	for j, candidate := range candidates[last] {
+		cost := costs[last][j] + corrector.getCost(candidate.Word, sentenceBoundary)
+		if cost < total {
+			total = cost
+			best = j
+		}
+	}
+	for i := last; i >= 0; i-- {
+		corrections[i].Word = candidates[i][best].Word
+		best = origins[i][best]
+	}
+
+	return SentenceCorrection{
+		Tokens: corrections,
+		Cost:   total,
+	}
+}
+
+// getCost returns the cost of the word following the previous one
+func (corrector *SentenceCorrector) getCost(previous string, word string) float64 {
+	return -math.Log(corrector.model.GetProbability(previous, word, corrector.BigramWeight))
+}
+
+// String returns the corrected sentence, the words being separated by a space
+func (correction SentenceCorrection) String() string {
+	words := make([]string, 0, len(correction.Tokens))
+	for _, token := range correction.Tokens {
+		words = append(words, token.Word)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,62 @@
+package levenshteinsearch
+
+import "testing"
+
+func createCorrectorModel() *BigramModel {
+	model := CreateBigramModel(nil)
+	for _, sentence := range [][]string{
+		{"they", "took", "their", "coats"},
+		{"they", "took", "their", "time"},
+		{"their", "house", "is", "over", "there"},
+		{"put", "it", "over", "there"},
+		{"over", "there", "is", "a", "cat"},
+		{"the", "white", "rabbit"},
+		{"the", "rabbit", "is", "late"},
+	} {
+		model.PutSentence(sentence)
+	}
+	return model
+}
+
+func TestSentenceCorrectorContext(t *testing.T) {
+
+	corrector := CreateSentenceCorrector(createCorrectorModel(), 2)
+	corrector.EditCost = 2
+
+	correction := corrector.Correct("they took there coats")
+	if correction.String() != "they took their coats" {
+		t.Errorf("Expected 'they took their coats', found '%v'", correction.String())
+	}
+
+	correction = corrector.Correct("put it over their")
+	if correction.String() != "put it over there" {
+		t.Errorf("Expected 'put it over there', found '%v'", correction.String())
+	}
+
+	token := correction.Tokens[3]
+	if token.Token != "their" || token.Word != "there" {
+		t.Errorf("Unexpected correction of the last token: %v", token)
+	}
+	if len(token.Alternatives) < 2 || token.Alternatives[0].Word != "their" || getResultPosition(token.Alternatives, "there") < 0 {
+		t.Errorf("Expected the alternatives to hold 'their' first, then 'there', found %v", token.Alternatives)
+	}
+}
+
+func TestSentenceCorrectorMisspelling(t *testing.T) {
+
+	corrector := CreateSentenceCorrector(createCorrectorModel(), 1)
+
+	correction := corrector.Correct("the whte rabit")
+	if correction.String() != "the white rabbit" {
+		t.Errorf("Expected 'the white rabbit', found '%v'", correction.String())
+	}
+
+	correction = corrector.Correct("the xyzzy is late")
+	if correction.String() != "the xyzzy is late" || len(correction.Tokens[1].Alternatives) != 0 {
+		t.Errorf("Expected an unknown token to be kept, found '%v'", correction.String())
+	}
+
+	if correction = corrector.Correct(""); len(correction.Tokens) != 0 || correction.String() != "" {
+		t.Error("Expected an empty sentence to have no token")
+	}
+}
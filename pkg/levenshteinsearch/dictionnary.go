@@ -5,6 +5,12 @@ type Dictionary struct {
 	Root            RuneTrie
 	WordCount       int
 	UniqueWordCount int
+	phonetic        *phoneticIndex
+	suffix          *suffixIndex
+	anagram         *anagramIndex
+	autoPrune       *autoPruner
+	// countsOutdated is true when words were added or removed since the counts of the nodes were computed
+	countsOutdated bool
 }
 
 // WordInformation holds the various information relative to a single word. As of now
@@ -17,6 +23,14 @@ type WordInformation struct {
 type RuneTrie struct {
 	information *WordInformation
 	children    map[rune]*RuneTrie
+	// wordCount is the number of different words of the node and its descendants. As the characters and
+	// wordsBefore, it is only up to date after updateCounts
+	wordCount int
+	// characters are the characters of the children, in increasing order
+	characters []rune
+	// wordsBefore gives, for each character, the number of words below the children of the lower characters.
+	// It has an additional last value, counting the words below all the children
+	wordsBefore []int
 }
 
 // NewRuneTrie allocates and returns a new *RuneTrie.
@@ -41,20 +55,61 @@ func NewRuneTrie() *RuneTrie {
 
 // Get returns the value stored at the given key. Returns nil if the key is not found.
 func (dictionary *Dictionary) Get(key string) *WordInformation {
-	node := &dictionary.Root
+	node := dictionary.Root.getNode(key)
+	if node == nil {
+		return nil
+	}
+	return node.information
+}
+
+// getNode returns the node below the current one at the given key. Returns nil if there is no such node.
+func (trie *RuneTrie) getNode(key string) *RuneTrie {
+	node := trie
 	for _, r := range key {
 		node = node.children[r]
 		if node == nil {
 			return nil
 		}
 	}
-	return node.information
+	return node
 }
 
 // Put inserts the value into the trie at the given key, updating any
 // existing information. It returns true if the put adds a new value, false
 // if it replaces an existing value.
 func (dictionary *Dictionary) Put(key string) bool {
+	return dictionary.PutWithCount(key, 1)
+}
+
+// PutWithCount inserts the value into the trie at the given key as if it was
+// put count times. It returns true if the put adds a new value, false if it
+// updates an existing value. A count lower than 1 is ignored.
+func (dictionary *Dictionary) PutWithCount(key string, count int) bool {
+	if count < 1 {
+		return false
+	}
+
+	if dictionary.autoPrune != nil {
+		return dictionary.autoPrune.put(dictionary, key, count)
+	}
+	return dictionary.putWithCount(key, count)
+}
+
+// Remove removes the word from the dictionary, whatever its count. It returns true if the word was found.
+func (dictionary *Dictionary) Remove(key string) bool {
+	information := dictionary.Get(key)
+	if information == nil {
+		return false
+	}
+
+	if dictionary.autoPrune != nil {
+		dictionary.autoPrune.remove(information)
+	}
+	return dictionary.removeWord(key)
+}
+
+// putWithCount inserts the value into the trie, without pruning
+func (dictionary *Dictionary) putWithCount(key string, count int) bool {
 	node := &dictionary.Root
 
 	// Rune by rune up to the node
@@ -72,15 +127,53 @@ func (dictionary *Dictionary) Put(key string) bool {
 	if node.information == nil {
 		isNewVal = true
 		node.information = &WordInformation{
-			Count: 1,
+			Count: count,
 		}
 		dictionary.UniqueWordCount++
+		dictionary.indexWord(key)
+		dictionary.countsOutdated = true
 	} else {
 		isNewVal = false
-		node.information.Count++
+		node.information.Count += count
 	}
 
-	dictionary.WordCount++
+	dictionary.WordCount += count
 
 	return isNewVal
 }
+
+// indexWord adds a new word to the optional indexes of the dictionary
+func (dictionary *Dictionary) indexWord(word string) {
+	if dictionary.phonetic != nil {
+		dictionary.phonetic.addWord(word)
+	}
+	if dictionary.suffix != nil {
+		dictionary.suffix.addWord(word)
+	}
+	if dictionary.anagram != nil {
+		dictionary.anagram.addWord(word)
+	}
+}
+
+// unindexWord removes a word from the optional indexes of the dictionary
+func (dictionary *Dictionary) unindexWord(word string) {
+	if dictionary.phonetic != nil {
+		dictionary.phonetic.removeWord(word)
+	}
+	if dictionary.suffix != nil {
+		dictionary.suffix.removeWord(word)
+	}
+	if dictionary.anagram != nil {
+		dictionary.anagram.removeWord(word)
+	}
+}
+
+// forEachWord calls visit for each word below the node, the prefix being the word of the node
+func (trie *RuneTrie) forEachWord(prefix string, visit func(word string, information *WordInformation)) {
+	if trie.information != nil {
+		visit(prefix, trie.information)
+	}
+	for character, child := range trie.children {
+		child.forEachWord(prefix+string(character), visit)
+	}
+}
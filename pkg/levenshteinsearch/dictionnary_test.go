@@ -59,3 +59,58 @@ func TestGetPut(t *testing.T) {
 		t.Error("Expected to not retrieve word info for 'monkey'")
 	}
 }
+
+func TestPutWithCount(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	if !dict.PutWithCount("banana", 5) {
+		t.Error("Expected 'banana' to be a new word")
+	}
+	if dict.PutWithCount("banana", 3) {
+		t.Error("Expected 'banana' to not be a new word anymore")
+	}
+	dict.Put("banana")
+	if dict.PutWithCount("orange", 0) {
+		t.Error("Expected a count of 0 to be ignored")
+	}
+
+	if dict.Get("banana").Count != 9 {
+		t.Error("Expected the word info for 'banana' to have a count of 9")
+	}
+	if dict.Get("orange") != nil {
+		t.Error("Expected 'orange' to not be in the dictionary")
+	}
+	if dict.WordCount != 9 {
+		t.Error("Expected the dictionnary to have 9 words")
+	}
+	if dict.UniqueWordCount != 1 {
+		t.Error("Expected the dictionnary to have 1 unique word")
+	}
+}
+
+func TestRemove(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("rabbit")
+	dict.Put("rabbit")
+	dict.Put("rabbits")
+
+	if !dict.Remove("rabbit") || dict.Remove("rabbit") || dict.Remove("rab") {
+		t.Error("Expected rabbit to be removed once")
+	}
+	if dict.Get("rabbit") != nil || dict.Get("rabbits") == nil {
+		t.Error("Expected only rabbits to remain")
+	}
+	if dict.WordCount != 1 || dict.UniqueWordCount != 1 {
+		t.Errorf("Unexpected counts after the removal: %v and %v", dict.WordCount, dict.UniqueWordCount)
+	}
+
+	dict.EnableAutoPrune(2, PruneSpaceSaving)
+	dict.Put("rat")
+	dict.Remove("rabbits")
+	dict.Put("cat")
+	if dict.UniqueWordCount != 2 || dict.Get("rat") == nil || dict.Get("cat") == nil {
+		t.Errorf("Unexpected words after the removal of a pruned dictionary: %v", dict.getAllWords())
+	}
+}
@@ -0,0 +1,621 @@
+package levenshteinsearch
+
+import (
+	"strings"
+)
+
+// DoubleMetaphoneEncoder encodes the words with the Double Metaphone algorithm of Lawrence Philips. Each word
+// gives a primary code and, for the words having an ambiguous pronunciation, an alternate code.
+type DoubleMetaphoneEncoder struct {
+	// MaxLength is the maximum length of the codes
+	MaxLength int
+}
+
+// CreateDoubleMetaphoneEncoder creates a new encoder generating codes of the usual length of 4
+func CreateDoubleMetaphoneEncoder() *DoubleMetaphoneEncoder {
+	return &DoubleMetaphoneEncoder{
+		MaxLength: 4,
+	}
+}
+
+// Encode returns the primary code of the word, followed by the alternate code if it is different. An empty
+// list is returned if the word has no code.
+func (encoder *DoubleMetaphoneEncoder) Encode(word string) []string {
+	primary, alternate := encoder.EncodeBoth(word)
+
+	codes := make([]string, 0, 2)
+	if primary != "" {
+		codes = append(codes, primary)
+	}
+	if alternate != "" && alternate != primary {
+		codes = append(codes, alternate)
+	}
+	return codes
+}
+
+// EncodeBoth returns the primary and the alternate codes of the word
+func (encoder *DoubleMetaphoneEncoder) EncodeBoth(word string) (string, string) {
+	value := []rune(strings.ToUpper(strings.TrimSpace(word)))
+	if len(value) == 0 {
+		return "", ""
+	}
+
+	metaphone := &doubleMetaphone{
+		value:         value,
+		maxLength:     encoder.MaxLength,
+		slavoGermanic: isSlavoGermanic(string(value)),
+	}
+
+	return metaphone.encode()
+}
+
+// doubleMetaphone holds the data of a single encoding
+type doubleMetaphone struct {
+	value         []rune
+	maxLength     int
+	slavoGermanic bool
+	primary       []rune
+	alternate     []rune
+}
+
+// encode runs the encoding and returns the primary and the alternate codes
+func (m *doubleMetaphone) encode() (string, string) {
+	index := 0
+	if m.contains(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		index = 1
+	}
+
+	for !m.isComplete() && index < len(m.value) {
+		switch m.value[index] {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if index == 0 {
+				m.append('A')
+			}
+			index++
+		case 'B':
+			m.append('P')
+			index = m.skipDouble(index, 'B')
+		case 'Ç':
+			m.append('S')
+			index++
+		case 'C':
+			index = m.handleC(index)
+		case 'D':
+			index = m.handleD(index)
+		case 'F':
+			m.append('F')
+			index = m.skipDouble(index, 'F')
+		case 'G':
+			index = m.handleG(index)
+		case 'H':
+			index = m.handleH(index)
+		case 'J':
+			index = m.handleJ(index)
+		case 'K':
+			m.append('K')
+			index = m.skipDouble(index, 'K')
+		case 'L':
+			index = m.handleL(index)
+		case 'M':
+			m.append('M')
+			if m.conditionM0(index) {
+				index += 2
+			} else {
+				index++
+			}
+		case 'N':
+			m.append('N')
+			index = m.skipDouble(index, 'N')
+		case 'Ñ':
+			m.append('N')
+			index++
+		case 'P':
+			index = m.handleP(index)
+		case 'Q':
+			m.append('K')
+			index = m.skipDouble(index, 'Q')
+		case 'R':
+			index = m.handleR(index)
+		case 'S':
+			index = m.handleS(index)
+		case 'T':
+			index = m.handleT(index)
+		case 'V':
+			m.append('F')
+			index = m.skipDouble(index, 'V')
+		case 'W':
+			index = m.handleW(index)
+		case 'X':
+			index = m.handleX(index)
+		case 'Z':
+			index = m.handleZ(index)
+		default:
+			index++
+		}
+	}
+
+	return string(m.primary), string(m.alternate)
+}
+
+func (m *doubleMetaphone) handleC(index int) int {
+	switch {
+	case m.conditionC0(index):
+		m.append('K')
+		index += 2
+	case index == 0 && m.contains(index, 6, "CAESAR"):
+		m.append('S')
+		index += 2
+	case m.contains(index, 2, "CH"):
+		index = m.handleCH(index)
+	case m.contains(index, 2, "CZ") && !m.contains(index-2, 4, "WICZ"):
+		m.appendBoth('S', 'X')
+		index += 2
+	case m.contains(index+1, 3, "CIA"):
+		m.append('X')
+		index += 3
+	case m.contains(index, 2, "CC") && !(index == 1 && m.charAt(0) == 'M'):
+		return m.handleCC(index)
+	case m.contains(index, 2, "CK", "CG", "CQ"):
+		m.append('K')
+		index += 2
+	case m.contains(index, 2, "CI", "CE", "CY"):
+		if m.contains(index, 3, "CIO", "CIE", "CIA") {
+			m.appendBoth('S', 'X')
+		} else {
+			m.append('S')
+		}
+		index += 2
+	default:
+		m.append('K')
+		if m.contains(index+1, 2, " C", " Q", " G") {
+			index += 3
+		} else if m.contains(index+1, 1, "C", "K", "Q") && !m.contains(index+1, 2, "CE", "CI") {
+			index += 2
+		} else {
+			index++
+		}
+	}
+	return index
+}
+
+func (m *doubleMetaphone) handleCC(index int) int {
+	if m.contains(index+2, 1, "I", "E", "H") && !m.contains(index+2, 2, "HU") {
+		if (index == 1 && m.charAt(index-1) == 'A') || m.contains(index-1, 5, "UCCEE", "UCCES") {
+			m.appendString("KS", "KS")
+		} else {
+			m.append('X')
+		}
+		return index + 3
+	}
+	m.append('K')
+	return index + 2
+}
+
+func (m *doubleMetaphone) handleCH(index int) int {
+	switch {
+	case index > 0 && m.contains(index, 4, "CHAE"):
+		m.appendBoth('K', 'X')
+	case m.conditionCH0(index), m.conditionCH1(index):
+		m.append('K')
+	case index > 0:
+		if m.contains(0, 2, "MC") {
+			m.append('K')
+		} else {
+			m.appendBoth('X', 'K')
+		}
+	default:
+		m.append('X')
+	}
+	return index + 2
+}
+
+func (m *doubleMetaphone) handleD(index int) int {
+	switch {
+	case m.contains(index, 2, "DG"):
+		if m.contains(index+2, 1, "I", "E", "Y") {
+			m.append('J')
+			return index + 3
+		}
+		m.appendString("TK", "TK")
+		return index + 2
+	case m.contains(index, 2, "DT", "DD"):
+		m.append('T')
+		return index + 2
+	default:
+		m.append('T')
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleG(index int) int {
+	switch {
+	case m.charAt(index+1) == 'H':
+		return m.handleGH(index)
+	case m.charAt(index+1) == 'N':
+		if index == 1 && isMetaphoneVowel(m.charAt(0)) && !m.slavoGermanic {
+			m.appendString("KN", "N")
+		} else if !m.contains(index+2, 2, "EY") && m.charAt(index+1) != 'Y' && !m.slavoGermanic {
+			m.appendString("N", "KN")
+		} else {
+			m.appendString("KN", "KN")
+		}
+		return index + 2
+	case m.contains(index+1, 2, "LI") && !m.slavoGermanic:
+		m.appendString("KL", "L")
+		return index + 2
+	case index == 0 && (m.charAt(index+1) == 'Y' || m.contains(index+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		m.appendBoth('K', 'J')
+		return index + 2
+	case (m.contains(index+1, 2, "ER") || m.charAt(index+1) == 'Y') &&
+		!m.contains(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!m.contains(index-1, 1, "E", "I") &&
+		!m.contains(index-1, 3, "RGY", "OGY"):
+		m.appendBoth('K', 'J')
+		return index + 2
+	case m.contains(index+1, 1, "E", "I", "Y") || m.contains(index-1, 4, "AGGI", "OGGI"):
+		if m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") || m.contains(index+1, 2, "ET") {
+			m.append('K')
+		} else if m.contains(index+1, 3, "IER") {
+			m.append('J')
+		} else {
+			m.appendBoth('J', 'K')
+		}
+		return index + 2
+	case m.charAt(index+1) == 'G':
+		m.append('K')
+		return index + 2
+	default:
+		m.append('K')
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleGH(index int) int {
+	switch {
+	case index > 0 && !isMetaphoneVowel(m.charAt(index-1)):
+		m.append('K')
+	case index == 0:
+		if m.charAt(index+2) == 'I' {
+			m.append('J')
+		} else {
+			m.append('K')
+		}
+	case (index > 1 && m.contains(index-2, 1, "B", "H", "D")) ||
+		(index > 2 && m.contains(index-3, 1, "B", "H", "D")) ||
+		(index > 3 && m.contains(index-4, 1, "B", "H")):
+		// Parker's rule (with some further refinements), as in "hugh"
+	default:
+		if index > 2 && m.charAt(index-1) == 'U' && m.contains(index-3, 1, "C", "G", "L", "R", "T") {
+			// As in "laugh", "McLaughlin", "cough", "gough", "rough", "tough"
+			m.append('F')
+		} else if index > 0 && m.charAt(index-1) != 'I' {
+			m.append('K')
+		}
+	}
+	return index + 2
+}
+
+func (m *doubleMetaphone) handleH(index int) int {
+	// Only kept if first and before a vowel, or between two vowels
+	if (index == 0 || isMetaphoneVowel(m.charAt(index-1))) && isMetaphoneVowel(m.charAt(index+1)) {
+		m.append('H')
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *doubleMetaphone) handleJ(index int) int {
+	if m.contains(index, 4, "JOSE") || m.contains(0, 4, "SAN ") {
+		// Obviously Spanish, as in "Jose" or "San Jacinto"
+		if (index == 0 && m.charAt(index+4) == ' ') || len(m.value) == 4 || m.contains(0, 4, "SAN ") {
+			m.append('H')
+		} else {
+			m.appendBoth('J', 'H')
+		}
+		return index + 1
+	}
+
+	if index == 0 && !m.contains(index, 4, "JOSE") {
+		m.appendBoth('J', 'A')
+	} else if isMetaphoneVowel(m.charAt(index-1)) && !m.slavoGermanic && (m.charAt(index+1) == 'A' || m.charAt(index+1) == 'O') {
+		m.appendBoth('J', 'H')
+	} else if index == len(m.value)-1 {
+		m.appendBoth('J', ' ')
+	} else if !m.contains(index+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !m.contains(index-1, 1, "S", "K", "L") {
+		m.append('J')
+	}
+
+	return m.skipDouble(index, 'J')
+}
+
+func (m *doubleMetaphone) handleL(index int) int {
+	if m.charAt(index+1) == 'L' {
+		if m.conditionL0(index) {
+			m.appendPrimary('L')
+		} else {
+			m.append('L')
+		}
+		return index + 2
+	}
+	m.append('L')
+	return index + 1
+}
+
+func (m *doubleMetaphone) handleP(index int) int {
+	if m.charAt(index+1) == 'H' {
+		m.append('F')
+		return index + 2
+	}
+	m.append('P')
+	if m.contains(index+1, 1, "P", "B") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *doubleMetaphone) handleR(index int) int {
+	if index == len(m.value)-1 && !m.slavoGermanic && m.contains(index-2, 2, "IE") && !m.contains(index-4, 2, "ME", "MA") {
+		m.appendAlternate('R')
+	} else {
+		m.append('R')
+	}
+	return m.skipDouble(index, 'R')
+}
+
+func (m *doubleMetaphone) handleS(index int) int {
+	switch {
+	case m.contains(index-1, 3, "ISL", "YSL"):
+		// Special cases "island", "isle", "carlisle", "carlysle"
+		return index + 1
+	case index == 0 && m.contains(index, 5, "SUGAR"):
+		m.appendBoth('X', 'S')
+		return index + 1
+	case m.contains(index, 2, "SH"):
+		if m.contains(index+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			m.append('S')
+		} else {
+			m.append('X')
+		}
+		return index + 2
+	case m.contains(index, 3, "SIO", "SIA") || m.contains(index, 4, "SIAN"):
+		if m.slavoGermanic {
+			m.append('S')
+		} else {
+			m.appendBoth('S', 'X')
+		}
+		return index + 3
+	case (index == 0 && m.contains(index+1, 1, "M", "N", "L", "W")) || m.contains(index+1, 1, "Z"):
+		m.appendBoth('S', 'X')
+		if m.contains(index+1, 1, "Z") {
+			return index + 2
+		}
+		return index + 1
+	case m.contains(index, 2, "SC"):
+		return m.handleSC(index)
+	default:
+		if index == len(m.value)-1 && m.contains(index-2, 2, "AI", "OI") {
+			m.appendAlternate('S')
+		} else {
+			m.append('S')
+		}
+		if m.contains(index+1, 1, "S", "Z") {
+			return index + 2
+		}
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleSC(index int) int {
+	if m.charAt(index+2) == 'H' {
+		if m.contains(index+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+			if m.contains(index+3, 2, "ER", "EN") {
+				m.appendString("X", "SK")
+			} else {
+				m.appendString("SK", "SK")
+			}
+		} else if index == 0 && !isMetaphoneVowel(m.charAt(3)) && m.charAt(3) != 'W' {
+			m.appendBoth('X', 'S')
+		} else {
+			m.append('X')
+		}
+	} else if m.contains(index+2, 1, "I", "E", "Y") {
+		m.append('S')
+	} else {
+		m.appendString("SK", "SK")
+	}
+	return index + 3
+}
+
+func (m *doubleMetaphone) handleT(index int) int {
+	switch {
+	case m.contains(index, 4, "TION"), m.contains(index, 3, "TIA", "TCH"):
+		m.append('X')
+		return index + 3
+	case m.contains(index, 2, "TH") || m.contains(index, 3, "TTH"):
+		if m.contains(index+2, 2, "OM", "AM") || m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") {
+			m.append('T')
+		} else {
+			m.appendBoth('0', 'T')
+		}
+		return index + 2
+	default:
+		m.append('T')
+		if m.contains(index+1, 1, "T", "D") {
+			return index + 2
+		}
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleW(index int) int {
+	switch {
+	case m.contains(index, 2, "WR"):
+		m.append('R')
+		return index + 2
+	case index == 0 && (isMetaphoneVowel(m.charAt(index+1)) || m.contains(index, 2, "WH")):
+		if isMetaphoneVowel(m.charAt(index + 1)) {
+			m.appendBoth('A', 'F')
+		} else {
+			m.append('A')
+		}
+		return index + 1
+	case (index == len(m.value)-1 && isMetaphoneVowel(m.charAt(index-1))) ||
+		m.contains(index-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		m.contains(0, 3, "SCH"):
+		m.appendAlternate('F')
+		return index + 1
+	case m.contains(index, 4, "WICZ", "WITZ"):
+		m.appendString("TS", "FX")
+		return index + 4
+	default:
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleX(index int) int {
+	if index == 0 {
+		m.append('S')
+		return index + 1
+	}
+	if !(index == len(m.value)-1 && (m.contains(index-3, 3, "IAU", "EAU") || m.contains(index-2, 2, "AU", "OU"))) {
+		m.appendString("KS", "KS")
+	}
+	if m.contains(index+1, 1, "C", "X") {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *doubleMetaphone) handleZ(index int) int {
+	if m.charAt(index+1) == 'H' {
+		m.append('J')
+		return index + 2
+	}
+	if m.contains(index+1, 2, "ZO", "ZI", "ZA") || (m.slavoGermanic && index > 0 && m.charAt(index-1) != 'T') {
+		m.appendString("S", "TS")
+	} else {
+		m.append('S')
+	}
+	return m.skipDouble(index, 'Z')
+}
+
+func (m *doubleMetaphone) conditionC0(index int) bool {
+	if m.contains(index, 4, "CHIA") {
+		return true
+	}
+	if index <= 1 || isMetaphoneVowel(m.charAt(index-2)) || !m.contains(index-1, 3, "ACH") {
+		return false
+	}
+	c := m.charAt(index + 2)
+	return (c != 'I' && c != 'E') || m.contains(index-2, 6, "BACHER", "MACHER")
+}
+
+func (m *doubleMetaphone) conditionCH0(index int) bool {
+	if index != 0 {
+		return false
+	}
+	if !m.contains(index+1, 5, "HARAC", "HARIS") && !m.contains(index+1, 3, "HOR", "HYM", "HIA", "HEM") {
+		return false
+	}
+	return !m.contains(0, 5, "CHORE")
+}
+
+func (m *doubleMetaphone) conditionCH1(index int) bool {
+	return m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") ||
+		m.contains(index-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		m.contains(index+2, 1, "T", "S") ||
+		((m.contains(index-1, 1, "A", "O", "U", "E") || index == 0) &&
+			(m.contains(index+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || index+1 == len(m.value)-1))
+}
+
+func (m *doubleMetaphone) conditionL0(index int) bool {
+	if index == len(m.value)-3 && m.contains(index-1, 4, "ILLO", "ILLA", "ALLE") {
+		return true
+	}
+	return (m.contains(len(m.value)-2, 2, "AS", "OS") || m.contains(len(m.value)-1, 1, "A", "O")) &&
+		m.contains(index-1, 4, "ALLE")
+}
+
+func (m *doubleMetaphone) conditionM0(index int) bool {
+	if m.charAt(index+1) == 'M' {
+		return true
+	}
+	return m.contains(index-1, 3, "UMB") && (index+1 == len(m.value)-1 || m.contains(index+2, 2, "ER"))
+}
+
+// skipDouble returns the index of the next rune to process, skipping the following rune if it is the same
+func (m *doubleMetaphone) skipDouble(index int, character rune) int {
+	if m.charAt(index+1) == character {
+		return index + 2
+	}
+	return index + 1
+}
+
+// charAt returns the rune at the given index, or 0 if the index is out of the value
+func (m *doubleMetaphone) charAt(index int) rune {
+	if index < 0 || index >= len(m.value) {
+		return 0
+	}
+	return m.value[index]
+}
+
+// contains returns true if the portion of the value of the given length starting at start is one of the
+// given criteria
+func (m *doubleMetaphone) contains(start int, length int, criteria ...string) bool {
+	if start < 0 || start+length > len(m.value) {
+		return false
+	}
+	target := string(m.value[start : start+length])
+	for _, criterion := range criteria {
+		if target == criterion {
+			return true
+		}
+	}
+	return false
+}
+
+// isComplete returns true if both codes reached their maximum length
+func (m *doubleMetaphone) isComplete() bool {
+	return len(m.primary) >= m.maxLength && len(m.alternate) >= m.maxLength
+}
+
+func (m *doubleMetaphone) append(value rune) {
+	m.appendPrimary(value)
+	m.appendAlternate(value)
+}
+
+func (m *doubleMetaphone) appendBoth(primary rune, alternate rune) {
+	m.appendPrimary(primary)
+	m.appendAlternate(alternate)
+}
+
+func (m *doubleMetaphone) appendPrimary(value rune) {
+	if len(m.primary) < m.maxLength {
+		m.primary = append(m.primary, value)
+	}
+}
+
+func (m *doubleMetaphone) appendAlternate(value rune) {
+	if len(m.alternate) < m.maxLength {
+		m.alternate = append(m.alternate, value)
+	}
+}
+
+func (m *doubleMetaphone) appendString(primary string, alternate string) {
+	for _, value := range primary {
+		m.appendPrimary(value)
+	}
+	for _, value := range alternate {
+		m.appendAlternate(value)
+	}
+}
+
+// isSlavoGermanic returns true if the word looks like a Slavic or a Germanic one
+func isSlavoGermanic(value string) bool {
+	return strings.ContainsAny(value, "WK") || strings.Contains(value, "CZ") || strings.Contains(value, "WITZ")
+}
+
+// isMetaphoneVowel returns true if the rune is a vowel for the Double Metaphone algorithm
+func isMetaphoneVowel(character rune) bool {
+	return strings.ContainsRune("AEIOUY", character)
+}
@@ -0,0 +1,43 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestDoubleMetaphone(t *testing.T) {
+
+	encoder := CreateDoubleMetaphoneEncoder()
+
+	expected := map[string][2]string{
+		"Smith":       {"SM0", "XMT"},
+		"Schmidt":     {"XMT", "SMT"},
+		"Thomas":      {"TMS", "TMS"},
+		"Knight":      {"NT", "NT"},
+		"Caesar":      {"SSR", "SSR"},
+		"Michael":     {"MKL", "MXL"},
+		"Jose":        {"HS", "HS"},
+		"Laugh":       {"LF", "LF"},
+		"Gnome":       {"NM", "NM"},
+		"Sugar":       {"XKR", "SKR"},
+		"Xavier":      {"SF", "SFR"},
+		"Arnoff":      {"ARNF", "ARNF"},
+		"Wright":      {"RT", "RT"},
+		"Alice":       {"ALS", "ALS"},
+		"Zhao":        {"J", "J"},
+		"Cabrillo":    {"KPRL", "KPR"},
+		"Bacchus":     {"PKS", "PKS"},
+		"Jankelowicz": {"JNKL", "ANKL"},
+	}
+
+	for word, codes := range expected {
+		primary, alternate := encoder.EncodeBoth(word)
+		if primary != codes[0] || alternate != codes[1] {
+			t.Errorf("Expected codes %v for '%v', found [%v %v]", codes, word, primary, alternate)
+		}
+	}
+
+	if len(encoder.Encode("")) != 0 {
+		t.Error("Expected no code for an empty word")
+	}
+	if len(encoder.Encode("Thomas")) != 1 {
+		t.Error("Expected a single code when the alternate code is the primary one")
+	}
+}
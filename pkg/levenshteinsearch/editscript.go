@@ -0,0 +1,206 @@
+package levenshteinsearch
+
+import (
+	"math"
+)
+
+// EditOperationType is the type of a single edit operation
+type EditOperationType int
+
+const (
+	// EditMatch means that the rune of the query is kept as is
+	EditMatch EditOperationType = iota
+	// EditSubstitute means that the rune of the query is replaced by the rune of the word
+	EditSubstitute
+	// EditInsert means that the rune of the word is inserted in the query
+	EditInsert
+	// EditDelete means that the rune of the query is removed
+	EditDelete
+	// EditTranspose means that two adjacent runes of the query are swapped
+	EditTranspose
+	// EditReplaceSequence means that a sequence of runes of the query is replaced by an equivalent sequence
+	// of the word, such as "ß" by "ss"
+	EditReplaceSequence
+)
+
+// String returns the name of the operation type
+func (operationType EditOperationType) String() string {
+	switch operationType {
+	case EditMatch:
+		return "match"
+	case EditSubstitute:
+		return "substitute"
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	case EditTranspose:
+		return "transpose"
+	case EditReplaceSequence:
+		return "replace sequence"
+	default:
+		return "unknown"
+	}
+}
+
+// EditOperation is a single operation of an edit script transforming a query into a word
+type EditOperation struct {
+	// Type is the type of the operation
+	Type EditOperationType
+	// QueryPosition is the position in runes of the operation in the query. For an insertion, it is the
+	// position before which the rune is inserted
+	QueryPosition int
+	// WordPosition is the position in runes of the operation in the word. For a deletion, it is the position
+	// before which the rune was removed
+	WordPosition int
+	// QueryRune is the rune of the query, or 0 for an insertion. For a transposition, it is the first of
+	// the two swapped runes
+	QueryRune rune
+	// WordRune is the rune of the word, or 0 for a deletion. For a transposition, it is the first of the two
+	// swapped runes
+	WordRune rune
+	// QueryLength is the number of runes of the query involved in the operation
+	QueryLength int
+	// WordLength is the number of runes of the word involved in the operation
+	WordLength int
+	// Cost is the cost of the operation: 0 for a match, 1 for the other operations unless the search
+	// options value them differently
+	Cost float64
+}
+
+// ComputeEditScript returns a minimal sequence of operations transforming the query into the word. If
+// withTranspositions is true, the swap of two adjacent runes counts as a single operation. Otherwise it
+// counts as two substitutions, as for the Levenshtein distance.
+func ComputeEditScript(query string, word string, withTranspositions bool) []EditOperation {
+	return computeEditScript([]rune(query), []rune(word), unitSubstitutionCost, nil, withTranspositions)
+}
+
+// computeEditScript returns a sequence of operations of minimal cost transforming the query into the word,
+// the substitutions being valued by the given function. A substitution without cost is given as a match.
+func computeEditScript(queryRunes []rune, wordRunes []rune, substitutionCost SubstitutionCost, sequences []sequenceRule, withTranspositions bool) []EditOperation {
+	distances := computeDistanceMatrix(queryRunes, wordRunes, substitutionCost, sequences, withTranspositions)
+
+	// Walk back the matrix from the end, preferring the diagonal
+	operations := make([]EditOperation, 0, max(len(queryRunes), len(wordRunes)))
+	i := len(queryRunes)
+	j := len(wordRunes)
+	for i > 0 || j > 0 {
+		current := distances[i][j]
+
+		cost := 1.0
+		if i > 0 && j > 0 {
+			cost = substitutionCost(queryRunes[i-1], wordRunes[j-1])
+		}
+
+		if i > 0 && j > 0 && current == distances[i-1][j-1]+cost {
+			i--
+			j--
+			operationType := EditSubstitute
+			if cost == 0 {
+				operationType = EditMatch
+			}
+			operations = append(operations, EditOperation{Type: operationType, QueryPosition: i, WordPosition: j, QueryRune: queryRunes[i], WordRune: wordRunes[j], QueryLength: 1, WordLength: 1, Cost: cost})
+			continue
+		}
+
+		if sequence := findSequence(queryRunes, wordRunes, i, j, sequences, distances); sequence != nil {
+			i -= len(sequence.termRunes)
+			j -= len(sequence.wordRunes)
+			operations = append(operations, EditOperation{Type: EditReplaceSequence, QueryPosition: i, WordPosition: j, QueryRune: queryRunes[i], WordRune: wordRunes[j], QueryLength: len(sequence.termRunes), WordLength: len(sequence.wordRunes), Cost: sequence.cost})
+			continue
+		}
+
+		switch {
+		case withTranspositions && isTransposition(queryRunes, wordRunes, i, j) && current == distances[i-2][j-2]+1:
+			i -= 2
+			j -= 2
+			operations = append(operations, EditOperation{Type: EditTranspose, QueryPosition: i, WordPosition: j, QueryRune: queryRunes[i], WordRune: wordRunes[j], QueryLength: 2, WordLength: 2, Cost: 1})
+		case i > 0 && current == distances[i-1][j]+1:
+			i--
+			operations = append(operations, EditOperation{Type: EditDelete, QueryPosition: i, WordPosition: j, QueryRune: queryRunes[i], QueryLength: 1, Cost: 1})
+		default:
+			j--
+			operations = append(operations, EditOperation{Type: EditInsert, QueryPosition: i, WordPosition: j, WordRune: wordRunes[j], WordLength: 1, Cost: 1})
+		}
+	}
+
+	// The operations were generated from the end
+	for left, right := 0, len(operations)-1; left < right; left, right = left+1, right-1 {
+		operations[left], operations[right] = operations[right], operations[left]
+	}
+
+	return operations
+}
+
+// GetEditDistance returns the number of operations of an edit script, not counting the operations without
+// cost such as the matches
+func GetEditDistance(operations []EditOperation) int {
+	distance := 0
+	for _, operation := range operations {
+		if operation.Cost != 0 {
+			distance++
+		}
+	}
+	return distance
+}
+
+// GetEditCost returns the total cost of the operations of an edit script
+func GetEditCost(operations []EditOperation) float64 {
+	cost := 0.0
+	for _, operation := range operations {
+		cost += operation.Cost
+	}
+	return cost
+}
+
+// computeDistanceMatrix computes the full matrix of the costs between all the prefixes of the query and of
+// the word. If withTranspositions is true, the optimal string alignment distance is used.
+func computeDistanceMatrix(queryRunes []rune, wordRunes []rune, substitutionCost SubstitutionCost, sequences []sequenceRule, withTranspositions bool) [][]float64 {
+	distances := make([][]float64, len(queryRunes)+1)
+	for i := range distances {
+		distances[i] = make([]float64, len(wordRunes)+1)
+		distances[i][0] = float64(i)
+	}
+	for j := range distances[0] {
+		distances[0][j] = float64(j)
+	}
+
+	for i := 1; i <= len(queryRunes); i++ {
+		for j := 1; j <= len(wordRunes); j++ {
+			cost := substitutionCost(queryRunes[i-1], wordRunes[j-1])
+			distances[i][j] = math.Min(math.Min(distances[i-1][j]+1, distances[i][j-1]+1), distances[i-1][j-1]+cost)
+			if withTranspositions && isTransposition(queryRunes, wordRunes, i, j) {
+				distances[i][j] = math.Min(distances[i][j], distances[i-2][j-2]+1)
+			}
+			for _, sequence := range sequences {
+				if endsWith(queryRunes[:i], sequence.termRunes) && endsWith(wordRunes[:j], sequence.wordRunes) {
+					distances[i][j] = math.Min(distances[i][j], distances[i-len(sequence.termRunes)][j-len(sequence.wordRunes)]+sequence.cost)
+				}
+			}
+		}
+	}
+
+	return distances
+}
+
+// findSequence returns the replacement of sequence giving the cost of the matrix at i and j, or nil if
+// there is none
+func findSequence(queryRunes []rune, wordRunes []rune, i int, j int, sequences []sequenceRule, distances [][]float64) *sequenceRule {
+	for index := range sequences {
+		sequence := &sequences[index]
+		if endsWith(queryRunes[:i], sequence.termRunes) && endsWith(wordRunes[:j], sequence.wordRunes) &&
+			distances[i][j] == distances[i-len(sequence.termRunes)][j-len(sequence.wordRunes)]+sequence.cost {
+			return sequence
+		}
+	}
+	return nil
+}
+
+// isTransposition returns true if the two runes of the query ending at i are the two runes of the word
+// ending at j, swapped
+func isTransposition(queryRunes []rune, wordRunes []rune, i int, j int) bool {
+	return i > 1 && j > 1 &&
+		queryRunes[i-1] == wordRunes[j-2] &&
+		queryRunes[i-2] == wordRunes[j-1] &&
+		queryRunes[i-1] != queryRunes[i-2]
+}
@@ -0,0 +1,65 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestComputeEditScript(t *testing.T) {
+
+	operations := ComputeEditScript("rabit", "rabbit", false)
+	if GetEditDistance(operations) != 1 {
+		t.Fatalf("Expected a single edit between 'rabit' and 'rabbit', found %v", operations)
+	}
+	for _, operation := range operations {
+		if operation.Type == EditInsert && operation.WordRune != 'b' {
+			t.Errorf("Expected the insertion of 'b', found %v", operation)
+		}
+	}
+
+	operations = ComputeEditScript("rsbbit", "rabbit", false)
+	if len(operations) != 6 || operations[1].Type != EditSubstitute || operations[1].QueryRune != 's' || operations[1].WordRune != 'a' {
+		t.Errorf("Expected the substitution of 's' by 'a', found %v", operations)
+	}
+
+	operations = ComputeEditScript("rabbits", "rabbit", false)
+	if GetEditDistance(operations) != 1 || operations[6].Type != EditDelete || operations[6].QueryRune != 's' {
+		t.Errorf("Expected the deletion of the last 's', found %v", operations)
+	}
+
+	operations = ComputeEditScript("raibbt", "rabbit", false)
+	if GetEditDistance(operations) != 2 {
+		t.Errorf("Expected 2 edits without transpositions, found %v", operations)
+	}
+
+	operations = ComputeEditScript("rbabit", "rabbit", true)
+	if GetEditDistance(operations) != 1 || operations[1].Type != EditTranspose {
+		t.Errorf("Expected a single transposition, found %v", operations)
+	}
+
+	operations = ComputeEditScript("", "", true)
+	if len(operations) != 0 {
+		t.Errorf("Expected no operation between empty strings, found %v", operations)
+	}
+}
+
+func TestEditScriptMatchesLevenshtein(t *testing.T) {
+
+	words := []string{"", "a", "banana", "bananas", "cabana", "abba", "baab", "foobarbaz"}
+	for _, query := range words {
+		for _, word := range words {
+			operations := ComputeEditScript(query, word, false)
+			if GetEditDistance(operations) != levenshtein([]rune(query), []rune(word)) {
+				t.Errorf("Unexpected edit script between '%v' and '%v': %v", query, word, operations)
+			}
+
+			// Replay the script to check it produces the word
+			result := make([]rune, 0)
+			for _, operation := range operations {
+				if operation.Type != EditDelete {
+					result = append(result, operation.WordRune)
+				}
+			}
+			if string(result) != word {
+				t.Errorf("Expected the edit script between '%v' and '%v' to produce the word, got '%v'", query, word, string(result))
+			}
+		}
+	}
+}
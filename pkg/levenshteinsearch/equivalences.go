@@ -0,0 +1,159 @@
+package levenshteinsearch
+
+import (
+	"unicode"
+)
+
+// Equivalences defines classes of equivalent strings, such as "e", "é" and "è", or "ß" and "ss". When used
+// in a search, replacing a member of a class by another member of the same class costs Cost instead of
+// the regular cost of the edits. The words stored in the dictionary are left untouched.
+type Equivalences struct {
+	// Cost is the cost of replacing a member of a class by another member of the same class
+	Cost float64
+
+	classesByRune map[rune][]int
+	classCount    int
+	sequences     [][2][]rune
+}
+
+// latinClasses are the classes of the usual accented Latin letters
+var latinClasses = [][]string{
+	{"a", "à", "á", "â", "ã", "ä", "å", "ā", "ă", "ą"},
+	{"c", "ç", "ć", "č"},
+	{"d", "ď", "đ"},
+	{"e", "è", "é", "ê", "ë", "ē", "ė", "ę", "ě"},
+	{"g", "ğ"},
+	{"i", "ì", "í", "î", "ï", "ī", "į", "ı"},
+	{"l", "ł", "ľ"},
+	{"n", "ñ", "ń", "ň"},
+	{"o", "ò", "ó", "ô", "õ", "ö", "ø", "ō", "ő"},
+	{"r", "ř"},
+	{"s", "ś", "š", "ş"},
+	{"t", "ť", "ţ"},
+	{"u", "ù", "ú", "û", "ü", "ū", "ů", "ű"},
+	{"y", "ý", "ÿ"},
+	{"z", "ź", "ż", "ž"},
+	{"ss", "ß"},
+	{"ae", "æ"},
+	{"oe", "œ"},
+}
+
+// CreateEquivalences creates a new empty set of classes
+func CreateEquivalences(cost float64) *Equivalences {
+	return &Equivalences{
+		Cost:          cost,
+		classesByRune: make(map[rune][]int),
+		classCount:    0,
+		sequences:     make([][2][]rune, 0),
+	}
+}
+
+// CreateLatinEquivalences creates a new set of classes holding the usual accented Latin letters, in lower and
+// upper case, along with the ligatures, and the full width forms of the ASCII characters
+func CreateLatinEquivalences(cost float64) *Equivalences {
+	equivalences := CreateEquivalences(cost)
+	equivalences.AddLatinClasses()
+	equivalences.AddFullWidthClasses()
+	return equivalences
+}
+
+// AddClass adds a class of equivalent strings. Most classes are made of single runes, but a class can also
+// hold longer strings, such as "ß" and "ss". A rune can be part of several classes.
+func (equivalences *Equivalences) AddClass(members ...string) {
+	class := equivalences.classCount
+	equivalences.classCount++
+
+	for i, first := range members {
+		firstRunes := []rune(first)
+		if len(firstRunes) == 1 {
+			equivalences.classesByRune[firstRunes[0]] = append(equivalences.classesByRune[firstRunes[0]], class)
+		}
+
+		// The replacements involving more than a single rune are kept as sequences, in both directions
+		for _, second := range members[i+1:] {
+			secondRunes := []rune(second)
+			if len(firstRunes) == 0 || len(secondRunes) == 0 || (len(firstRunes) == 1 && len(secondRunes) == 1) {
+				continue
+			}
+			equivalences.sequences = append(equivalences.sequences,
+				[2][]rune{firstRunes, secondRunes},
+				[2][]rune{secondRunes, firstRunes})
+		}
+	}
+}
+
+// AddLatinClasses adds the classes of the usual accented Latin letters, in lower and upper case, along with
+// the ligatures
+func (equivalences *Equivalences) AddLatinClasses() {
+	for _, class := range latinClasses {
+		equivalences.AddClass(class...)
+
+		upperClass := make([]string, 0, len(class))
+		for _, member := range class {
+			upper := []rune(member)
+			for i, r := range upper {
+				upper[i] = unicode.ToUpper(r)
+			}
+			// Some letters, such as "ß", are their own upper case
+			if string(upper) != member {
+				upperClass = append(upperClass, string(upper))
+			}
+		}
+		if len(upperClass) > 1 {
+			equivalences.AddClass(upperClass...)
+		}
+	}
+}
+
+// AddFullWidthClasses adds the classes of the ASCII characters and their full width forms, such as "1"
+// and "１"
+func (equivalences *Equivalences) AddFullWidthClasses() {
+	for r := rune(0x21); r <= 0x7E; r++ {
+		equivalences.AddClass(string(r), string(r+0xFEE0))
+	}
+}
+
+// AreEquivalent returns true if the two runes are different but belong to the same class
+func (equivalences *Equivalences) AreEquivalent(first rune, second rune) bool {
+	if first == second {
+		return false
+	}
+	for _, firstClass := range equivalences.classesByRune[first] {
+		for _, secondClass := range equivalences.classesByRune[second] {
+			if firstClass == secondClass {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetSubstitutionCost returns the cost of replacing the rune of the searched term by the rune of the word
+func (equivalences *Equivalences) GetSubstitutionCost(termRune rune, wordRune rune) float64 {
+	if termRune == wordRune {
+		return 0
+	}
+	if equivalences.AreEquivalent(termRune, wordRune) {
+		return equivalences.Cost
+	}
+	return 1
+}
+
+// getSequenceRules returns the replacements of sequences of runes defined by the classes
+func (equivalences *Equivalences) getSequenceRules() []sequenceRule {
+	rules := make([]sequenceRule, 0, len(equivalences.sequences))
+	for _, sequence := range equivalences.sequences {
+		rules = append(rules, sequenceRule{
+			termRunes: sequence[0],
+			wordRunes: sequence[1],
+			cost:      equivalences.Cost,
+		})
+	}
+	return rules
+}
+
+// CreateEquivalenceAutomaton creates a new automaton in which the members of the same class are replaced
+// for the cost defined by the equivalences
+func CreateEquivalenceAutomaton(searchedTerm string, distanceMax float64, equivalences *Equivalences) *WeightedAutomaton {
+	return createWeightedAutomaton(searchedTerm, distanceMax, equivalences.GetSubstitutionCost, equivalences.getSequenceRules())
+}
@@ -0,0 +1,136 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestEquivalencesAreEquivalent(t *testing.T) {
+
+	equivalences := CreateLatinEquivalences(0.1)
+
+	if !equivalences.AreEquivalent('e', 'é') || !equivalences.AreEquivalent('é', 'è') {
+		t.Error("Expected 'e', 'é' and 'è' to be equivalent")
+	}
+	if !equivalences.AreEquivalent('E', 'É') {
+		t.Error("Expected 'E' and 'É' to be equivalent")
+	}
+	if equivalences.AreEquivalent('e', 'É') {
+		t.Error("Expected 'e' and 'É' to not be equivalent")
+	}
+	if !equivalences.AreEquivalent('1', '１') {
+		t.Error("Expected '1' and its full width form to be equivalent")
+	}
+	if equivalences.AreEquivalent('e', 'e') {
+		t.Error("Expected a rune to not be equivalent to itself")
+	}
+	if equivalences.GetSubstitutionCost('a', 'à') != 0.1 || equivalences.GetSubstitutionCost('a', 'b') != 1 {
+		t.Error("Unexpected substitution costs")
+	}
+}
+
+func TestEquivalenceAutomatonSequences(t *testing.T) {
+
+	equivalences := CreateLatinEquivalences(0.25)
+
+	testCases := []struct {
+		term  string
+		word  string
+		cost  float64
+		edits int
+	}{
+		{"strasse", "straße", 0.25, 1},
+		{"straße", "strasse", 0.25, 1},
+		{"cafe", "café", 0.25, 1},
+		{"coeur", "cœur", 0.25, 1},
+		{"Ecole", "École", 0.25, 1},
+		{"１２３", "123", 0.75, 3},
+	}
+
+	for _, testCase := range testCases {
+		automaton := CreateEquivalenceAutomaton(testCase.term, 1, equivalences)
+		state := automaton.Start()
+		for _, c := range testCase.word {
+			state = automaton.Step(state, c)
+		}
+		if !automaton.IsMatch(state) {
+			t.Errorf("Expected '%v' to match '%v'", testCase.word, testCase.term)
+			continue
+		}
+		if cost, edits := automaton.getCost(state); cost != testCase.cost || edits != testCase.edits {
+			t.Errorf("Expected %v edits for %v between '%v' and '%v', found %v edits for %v", testCase.edits, testCase.cost, testCase.term, testCase.word, edits, cost)
+		}
+	}
+}
+
+func TestEquivalenceAutomatonMatchesDistanceMatrix(t *testing.T) {
+
+	equivalences := CreateLatinEquivalences(0.5)
+	sequences := equivalences.getSequenceRules()
+	words := []string{"", "s", "ß", "ss", "strasse", "straße", "strase", "stræsse", "caffé", "cafe", "ae", "æ"}
+
+	for _, term := range words {
+		for _, word := range words {
+			termRunes := []rune(term)
+			wordRunes := []rune(word)
+			distances := computeDistanceMatrix(termRunes, wordRunes, equivalences.GetSubstitutionCost, sequences, false)
+			expected := distances[len(termRunes)][len(wordRunes)]
+
+			for _, distanceMax := range []float64{0, 0.5, 1, 2} {
+				automaton := CreateEquivalenceAutomaton(term, distanceMax, equivalences)
+				state := automaton.Start()
+				for _, c := range word {
+					state = automaton.Step(state, c)
+				}
+				if automaton.IsMatch(state) != (expected <= distanceMax) {
+					t.Errorf("Unexpected match result for '%v' and '%v' with a maximum of %v", term, word, distanceMax)
+				}
+				if automaton.IsMatch(state) {
+					if cost, _ := automaton.getCost(state); cost != expected {
+						t.Errorf("Expected a cost of %v for '%v' and '%v', found %v", expected, term, word, cost)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSearchWithEquivalences(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("straße")
+	dict.Put("strasse")
+	dict.Put("trasse")
+	dict.Put("café")
+
+	results := dict.SearchWithOptions("strasse", SearchOptions{
+		DistanceMax:    1,
+		Equivalences:   CreateLatinEquivalences(0.1),
+		WithEditScript: true,
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, found %v", len(results))
+	}
+	if results[0].Word != "strasse" || results[1].Word != "straße" || results[2].Word != "trasse" {
+		t.Errorf("Unexpected order of the results: %v, %v, %v", results[0].Word, results[1].Word, results[2].Word)
+	}
+	if results[1].Cost != 0.1 || results[1].Distance != 1 {
+		t.Errorf("Expected 'straße' to have a cost of 0.1 for a single edit, found %v for %v", results[1].Cost, results[1].Distance)
+	}
+
+	script := results[1].EditScript
+	if GetEditDistance(script) != 1 || GetEditCost(script) != 0.1 {
+		t.Errorf("Unexpected edit script for 'straße': %v", script)
+	}
+	for _, operation := range script {
+		if operation.Type == EditReplaceSequence && (operation.QueryPosition != 4 || operation.QueryLength != 2 || operation.WordLength != 1) {
+			t.Errorf("Unexpected replacement of sequence: %v", operation)
+		}
+	}
+
+	results = dict.SearchWithOptions("cafe", SearchOptions{
+		DistanceMax:  0,
+		Equivalences: CreateLatinEquivalences(0),
+	})
+	if len(results) != 1 || results[0].Word != "café" {
+		t.Error("Expected 'café' to be found without cost for 'cafe'")
+	}
+}
@@ -0,0 +1,157 @@
+package levenshteinsearch
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FrequencyFormat describes a frequency list: a text file giving on each line a word and its count
+type FrequencyFormat struct {
+	// Delimiter separates the word from its count. The fields may be quoted as in a CSV file
+	Delimiter rune
+	// HasHeader is true if the first line is a header to skip on import and to write on export
+	HasHeader bool
+	// MinCount is the minimum count of the words imported or exported. The other words are skipped
+	MinCount int
+}
+
+// MalformedLine is a line of a frequency list that could not be imported
+type MalformedLine struct {
+	// Line is the number of the line, starting at 1
+	Line int
+	// Text is the content of the line
+	Text string
+	// Reason explains why the line is malformed
+	Reason string
+}
+
+// ImportReport summarizes the import of a frequency list
+type ImportReport struct {
+	// ImportedCount is the number of lines imported
+	ImportedCount int
+	// SkippedCount is the number of lines skipped because of their count being lower than the minimum
+	SkippedCount int
+	// MalformedLines are the lines that could not be imported
+	MalformedLines []MalformedLine
+}
+
+// frequencyHeader is the header written on export
+var frequencyHeader = []string{"word", "count"}
+
+// CreateTSVFormat creates the format of the frequency lists separated by tabulations, without header
+func CreateTSVFormat() FrequencyFormat {
+	return FrequencyFormat{
+		Delimiter: '\t',
+		HasHeader: false,
+		MinCount:  1,
+	}
+}
+
+// CreateCSVFormat creates the format of the frequency lists separated by commas, with a header
+func CreateCSVFormat() FrequencyFormat {
+	return FrequencyFormat{
+		Delimiter: ',',
+		HasHeader: true,
+		MinCount:  1,
+	}
+}
+
+// ImportFrequencies puts in the dictionary each word of the frequency list with its count. The columns
+// following the count are ignored. The malformed lines are skipped and given in the report, an error only
+// being returned if the reader fails.
+func (dictionary *Dictionary) ImportFrequencies(reader io.Reader, format FrequencyFormat) (ImportReport, error) {
+	report := ImportReport{
+		MalformedLines: make([]MalformedLine, 0),
+	}
+
+	scanner := bufio.NewScanner(reader)
+	number := 0
+	for scanner.Scan() {
+		number++
+		line := scanner.Text()
+		if (number == 1 && format.HasHeader) || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		word, count, err := parseFrequencyLine(line, format.Delimiter)
+		if err != nil {
+			report.MalformedLines = append(report.MalformedLines, MalformedLine{Line: number, Text: line, Reason: err.Error()})
+			continue
+		}
+		if count < format.MinCount {
+			report.SkippedCount++
+			continue
+		}
+
+		dictionary.PutWithCount(word, count)
+		report.ImportedCount++
+	}
+
+	return report, scanner.Err()
+}
+
+// parseFrequencyLine returns the word and the count of a line of a frequency list
+func parseFrequencyLine(line string, delimiter rune) (string, int, error) {
+	fieldReader := csv.NewReader(strings.NewReader(line))
+	fieldReader.Comma = delimiter
+	fieldReader.LazyQuotes = true
+	fieldReader.FieldsPerRecord = -1
+
+	fields, err := fieldReader.Read()
+	if err != nil {
+		return "", 0, err
+	}
+	if len(fields) < 2 {
+		return "", 0, fmt.Errorf("expected a word and a count, found %v field(s)", len(fields))
+	}
+
+	word := strings.TrimSpace(fields[0])
+	if word == "" {
+		return "", 0, fmt.Errorf("empty word")
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+	if err != nil || count < 1 {
+		return "", 0, fmt.Errorf("invalid count %v", fields[1])
+	}
+
+	return word, count, nil
+}
+
+// ExportFrequencies writes each word of the dictionary with its count, by decreasing count, then
+// alphabetically
+func (dictionary *Dictionary) ExportFrequencies(writer io.Writer, format FrequencyFormat) error {
+	results := make([]SearchResult, 0, dictionary.UniqueWordCount)
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		if information.Count >= format.MinCount {
+			results = append(results, SearchResult{Word: word, Information: information})
+		}
+	})
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Information.Count != results[j].Information.Count {
+			return results[i].Information.Count > results[j].Information.Count
+		}
+		return results[i].Word < results[j].Word
+	})
+
+	fieldWriter := csv.NewWriter(writer)
+	fieldWriter.Comma = format.Delimiter
+
+	if format.HasHeader {
+		if err := fieldWriter.Write(frequencyHeader); err != nil {
+			return err
+		}
+	}
+	for _, result := range results {
+		if err := fieldWriter.Write([]string{result.Word, strconv.Itoa(result.Information.Count)}); err != nil {
+			return err
+		}
+	}
+
+	fieldWriter.Flush()
+	return fieldWriter.Error()
+}
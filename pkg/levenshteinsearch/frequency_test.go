@@ -0,0 +1,92 @@
+package levenshteinsearch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestImportFrequencies(t *testing.T) {
+
+	list := "the\t1000\nrabbit\t12\nalice\t\t3\nqueen\tmany\n\nhatter\t2\tnoun\ncat\t1\nthe\t10\n\t4\n"
+
+	dict := CreateDictionary()
+	format := CreateTSVFormat()
+	format.MinCount = 2
+	report, err := dict.ImportFrequencies(strings.NewReader(list), format)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ImportedCount != 4 || report.SkippedCount != 1 {
+		t.Errorf("Expected 4 lines imported and 1 skipped, found %v and %v", report.ImportedCount, report.SkippedCount)
+	}
+	if len(report.MalformedLines) != 3 {
+		t.Fatalf("Expected 3 malformed lines, found %v", report.MalformedLines)
+	}
+	if report.MalformedLines[0].Line != 3 || report.MalformedLines[1].Line != 4 || report.MalformedLines[2].Line != 9 {
+		t.Errorf("Unexpected malformed lines: %v", report.MalformedLines)
+	}
+
+	if dict.Get("the").Count != 1010 || dict.Get("hatter").Count != 2 || dict.Get("cat") != nil {
+		t.Error("Unexpected counts in the dictionary")
+	}
+	if dict.WordCount != 1024 || dict.UniqueWordCount != 3 {
+		t.Errorf("Expected 1024 words and 3 unique words, found %v and %v", dict.WordCount, dict.UniqueWordCount)
+	}
+}
+
+func TestImportFrequenciesCSV(t *testing.T) {
+
+	list := "word,count\r\n\"hello, world\",3\r\nrabbit, 12\r\n"
+
+	dict := CreateDictionary()
+	report, err := dict.ImportFrequencies(strings.NewReader(list), CreateCSVFormat())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ImportedCount != 2 || len(report.MalformedLines) != 0 {
+		t.Errorf("Unexpected report: %v", report)
+	}
+	if dict.Get("hello, world").Count != 3 || dict.Get("rabbit").Count != 12 {
+		t.Error("Expected the quoted words and the spaces to be handled")
+	}
+}
+
+func TestExportFrequencies(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.PutWithCount("rabbit", 12)
+	dict.PutWithCount("the", 1000)
+	dict.PutWithCount("alice", 12)
+	dict.PutWithCount("cat", 1)
+	dict.PutWithCount("hello, world", 3)
+
+	var buffer bytes.Buffer
+	format := CreateCSVFormat()
+	format.MinCount = 2
+	if err := dict.ExportFrequencies(&buffer, format); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "word,count\nthe,1000\nalice,12\nrabbit,12\n\"hello, world\",3\n"
+	if buffer.String() != expected {
+		t.Errorf("Unexpected export:\n%v", buffer.String())
+	}
+
+	// The export can be imported back
+	imported := CreateDictionary()
+	report, err := imported.ImportFrequencies(&buffer, format)
+	if err != nil || report.ImportedCount != 4 || imported.WordCount != 1027 {
+		t.Errorf("Expected the export to be imported back, found %v", report)
+	}
+
+	buffer.Reset()
+	if err := dict.ExportFrequencies(&buffer, CreateTSVFormat()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buffer.String(), "the\t1000\n") || !strings.HasSuffix(buffer.String(), "cat\t1\n") {
+		t.Errorf("Unexpected TSV export:\n%v", buffer.String())
+	}
+}
@@ -0,0 +1,99 @@
+package levenshteinsearch
+
+import (
+	"unicode/utf8"
+)
+
+// Fuzziness is a policy giving the maximum distance allowed for a searched term from its length. By
+// default, the distance depends on two length thresholds: no edit is allowed for the terms shorter than
+// LowLength, a single edit for the terms shorter than HighLength and two edits for the longer terms. If
+// Ratio is set, the distance is instead the given ratio of the length of the term, rounded down.
+//
+// In both cases, if MaxNormalizedDistance is set, the words are only accepted if their distance divided by
+// the length of the longest of the term and the word does not exceed it.
+type Fuzziness struct {
+	// LowLength is the length (in runes) from which a single edit is allowed
+	LowLength int
+	// HighLength is the length (in runes) from which two edits are allowed
+	HighLength int
+	// Ratio is, if greater than 0, the maximum number of edits per rune of the term
+	Ratio float64
+	// MaxNormalizedDistance is, if greater than 0, the maximum normalized distance of the accepted words
+	MaxNormalizedDistance float64
+}
+
+// CreateAutoFuzziness creates a fuzziness allowing no edit for the terms shorter than lowLength, a single
+// edit for the terms shorter than highLength, and two edits for the longer terms
+func CreateAutoFuzziness(lowLength int, highLength int) *Fuzziness {
+	return &Fuzziness{
+		LowLength:  lowLength,
+		HighLength: highLength,
+	}
+}
+
+// CreateDefaultFuzziness creates the usual fuzziness of the search engines: no edit up to 2 runes, a single
+// edit up to 5 runes and two edits for the longer terms
+func CreateDefaultFuzziness() *Fuzziness {
+	return CreateAutoFuzziness(3, 6)
+}
+
+// CreateRatioFuzziness creates a fuzziness allowing a number of edits proportional to the length of the terms
+func CreateRatioFuzziness(ratio float64) *Fuzziness {
+	return &Fuzziness{
+		Ratio: ratio,
+	}
+}
+
+// GetDistanceMax returns the maximum distance allowed for the given searched term
+func (fuzziness *Fuzziness) GetDistanceMax(searchedTerm string) int {
+	length := utf8.RuneCountInString(searchedTerm)
+
+	if fuzziness.Ratio > 0 {
+		return int(fuzziness.Ratio * float64(length))
+	}
+
+	switch {
+	case length < fuzziness.LowLength:
+		return 0
+	case length < fuzziness.HighLength:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Accepts returns true if the word found at the given distance of the searched term is accepted
+func (fuzziness *Fuzziness) Accepts(searchedTerm string, word string, distance int) bool {
+	if distance > fuzziness.GetDistanceMax(searchedTerm) {
+		return false
+	}
+	if fuzziness.MaxNormalizedDistance > 0 {
+		return GetNormalizedDistance(searchedTerm, word, distance) <= fuzziness.MaxNormalizedDistance
+	}
+	return true
+}
+
+// GetNormalizedDistance returns the distance divided by the length (in runes) of the longest of the searched
+// term and the word. The result is between 0 (identical) and 1 (nothing in common).
+func GetNormalizedDistance(searchedTerm string, word string, distance int) float64 {
+	length := max(utf8.RuneCountInString(searchedTerm), utf8.RuneCountInString(word))
+	if length == 0 {
+		return 0
+	}
+	return float64(distance) / float64(length)
+}
+
+// SearchAllFuzzy returns all the words of the dictionary accepted by the fuzziness for the searched term
+func (dictionary *Dictionary) SearchAllFuzzy(searchedTerm string, fuzziness *Fuzziness) map[string]*WordInformation {
+	automaton := CreateAutomaton(searchedTerm, fuzziness.GetDistanceMax(searchedTerm))
+
+	results := map[string]*WordInformation{}
+
+	dictionary.Root.searchAll(automaton, "", nil, nil, func(word string, information *WordInformation, state State) {
+		if fuzziness.Accepts(searchedTerm, word, automaton.distance(state.(AutomatonState))) {
+			results[word] = information
+		}
+	})
+
+	return results
+}
@@ -0,0 +1,55 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestFuzzinessDistanceMax(t *testing.T) {
+
+	fuzziness := CreateDefaultFuzziness()
+	expected := map[string]int{"": 0, "to": 0, "the": 1, "rabbit": 2, "platyhelminth": 2}
+	for term, distance := range expected {
+		if fuzziness.GetDistanceMax(term) != distance {
+			t.Errorf("Expected a distance of %v for '%v', found %v", distance, term, fuzziness.GetDistanceMax(term))
+		}
+	}
+
+	fuzziness = CreateRatioFuzziness(0.25)
+	expected = map[string]int{"the": 0, "rabbit": 1, "platyhelminth": 3}
+	for term, distance := range expected {
+		if fuzziness.GetDistanceMax(term) != distance {
+			t.Errorf("Expected a distance of %v for '%v', found %v", distance, term, fuzziness.GetDistanceMax(term))
+		}
+	}
+}
+
+func TestSearchAllFuzzy(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("the")
+	dict.Put("she")
+	dict.Put("they")
+	dict.Put("platyhelminth")
+
+	result := dict.SearchAllFuzzy("the", CreateDefaultFuzziness())
+	if len(result) != 3 {
+		t.Errorf("Expected to find 'the', 'she' and 'they', found %v", result)
+	}
+
+	result = dict.SearchAllFuzzy("platyhelmynt", CreateDefaultFuzziness())
+	if len(result) != 1 || result["platyhelminth"] == nil {
+		t.Errorf("Expected to find 'platyhelminth', found %v", result)
+	}
+
+	// "she" has 1 edit for 3 runes, "they" 1 edit for 4 runes
+	fuzziness := CreateDefaultFuzziness()
+	fuzziness.MaxNormalizedDistance = 0.3
+	result = dict.SearchAllFuzzy("the", fuzziness)
+	if len(result) != 2 || result["the"] == nil || result["they"] == nil {
+		t.Errorf("Expected to find 'the' and 'they' with a normalized distance of 0.3, found %v", result)
+	}
+
+	results := dict.SearchWithOptions("the", SearchOptions{Fuzziness: fuzziness})
+	if len(results) != 2 || results[1].Word != "they" || results[1].NormalizedDistance != 0.25 {
+		t.Errorf("Expected to find 'the' and 'they' with a normalized distance of 0.3, found %v", results)
+	}
+}
@@ -0,0 +1,638 @@
+package levenshteinsearch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// HunspellDictionary holds the stems of a Hunspell dictionary along with the affix rules that apply to
+// them. The rules are applied lazily when checking or searching words, so that the inflected forms do not
+// need to be stored. They can nevertheless be expanded into a regular Dictionary.
+//
+// Only the basic affix rules are supported: PFX and SFX, with their cross product, along with the flag
+// aliases (AF), the stems needing an affix (NEEDAFFIX) and the forbidden stems (FORBIDDENWORD). The compounds
+// are not generated: the compounding options (COMPOUNDRULE, COMPOUNDFLAG...) are skipped, and the stems only
+// allowed inside compounds (ONLYINCOMPOUND) are left out. The continuation classes and the other options of
+// the affix file are ignored.
+type HunspellDictionary struct {
+	// Stems is the dictionary of the stems, as listed in the .dic file
+	Stems *Dictionary
+
+	flagsByStem    map[string]map[string]bool
+	prefixes       []*affixEntry
+	suffixes       []*affixEntry
+	affixesByFlag  map[string][]*affixEntry
+	flagType       string
+	flagAliases    []string
+	needAffix      string
+	onlyInCompound string
+	forbiddenWord  string
+	encoding       string
+}
+
+// affixEntry is a single rule of an affix class
+type affixEntry struct {
+	flag         string
+	prefix       bool
+	crossProduct bool
+	strip        []rune
+	add          []rune
+	condition    []conditionElement
+}
+
+// conditionElement is a single rune of the condition of an affix rule: either any rune, or a set of runes
+// that is possibly negated
+type conditionElement struct {
+	any     bool
+	negated bool
+	runes   []rune
+}
+
+// affixDecomposition is a possible decomposition of a word into a stem and its affixes
+type affixDecomposition struct {
+	stem   string
+	prefix *affixEntry
+	suffix *affixEntry
+}
+
+// LoadHunspellFiles loads a Hunspell dictionary from its .dic and .aff files
+func LoadHunspellFiles(dicPath string, affPath string) (*HunspellDictionary, error) {
+	dicFile, err := os.Open(dicPath)
+	if err != nil {
+		return nil, err
+	}
+	defer dicFile.Close()
+
+	affFile, err := os.Open(affPath)
+	if err != nil {
+		return nil, err
+	}
+	defer affFile.Close()
+
+	return LoadHunspell(dicFile, affFile)
+}
+
+// LoadHunspell loads a Hunspell dictionary from the content of its .dic and .aff files. The files may be
+// encoded in UTF-8 or in ISO8859-1, as given by the option SET of the affix file.
+func LoadHunspell(dicReader io.Reader, affReader io.Reader) (*HunspellDictionary, error) {
+	hunspell := &HunspellDictionary{
+		Stems:         CreateDictionary(),
+		flagsByStem:   make(map[string]map[string]bool),
+		prefixes:      make([]*affixEntry, 0),
+		suffixes:      make([]*affixEntry, 0),
+		affixesByFlag: make(map[string][]*affixEntry),
+		flagType:      "",
+		encoding:      "UTF-8",
+	}
+
+	if err := hunspell.readAffixes(affReader); err != nil {
+		return nil, err
+	}
+	if err := hunspell.readStems(dicReader); err != nil {
+		return nil, err
+	}
+
+	return hunspell, nil
+}
+
+// readAffixes reads the affix file
+func (hunspell *HunspellDictionary) readAffixes(reader io.Reader) error {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	// The encoding is needed before decoding the file
+	encodingScanner := bufio.NewScanner(bytes.NewReader(data))
+	for encodingScanner.Scan() {
+		fields := strings.Fields(encodingScanner.Text())
+		if len(fields) >= 2 && fields[0] == "SET" {
+			hunspell.encoding = strings.ToUpper(fields[1])
+		}
+	}
+
+	lines, err := hunspell.decodeLines(data)
+	if err != nil {
+		return err
+	}
+
+	// Number of rules remaining for the current affix class, and whether it allows the cross product
+	remaining := 0
+	crossProduct := false
+	// The first AF line gives the number of aliases
+	aliasesStarted := false
+	for number, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "FLAG":
+			if len(fields) < 2 {
+				return fmt.Errorf("line %v: missing flag type", number+1)
+			}
+			switch fields[1] {
+			case "long", "num", "UTF-8":
+				hunspell.flagType = fields[1]
+			default:
+				return fmt.Errorf("line %v: unsupported flag type %v", number+1, fields[1])
+			}
+		case "AF":
+			if len(fields) < 2 {
+				return fmt.Errorf("line %v: missing flag alias", number+1)
+			}
+			if !aliasesStarted {
+				count, err := strconv.Atoi(fields[1])
+				if err != nil {
+					return fmt.Errorf("line %v: invalid number of flag aliases %v", number+1, fields[1])
+				}
+				hunspell.flagAliases = make([]string, 0, count)
+				aliasesStarted = true
+				continue
+			}
+			hunspell.flagAliases = append(hunspell.flagAliases, fields[1])
+		case "NEEDAFFIX", "ONLYINCOMPOUND", "FORBIDDENWORD":
+			if len(fields) < 2 {
+				return fmt.Errorf("line %v: missing flag", number+1)
+			}
+			switch fields[0] {
+			case "NEEDAFFIX":
+				hunspell.needAffix = fields[1]
+			case "ONLYINCOMPOUND":
+				hunspell.onlyInCompound = fields[1]
+			default:
+				hunspell.forbiddenWord = fields[1]
+			}
+		case "PFX", "SFX":
+			if remaining == 0 {
+				// Header of a class: flag, cross product and number of rules
+				if len(fields) < 4 {
+					return fmt.Errorf("line %v: invalid affix class header", number+1)
+				}
+				count, err := strconv.Atoi(fields[3])
+				if err != nil {
+					return fmt.Errorf("line %v: invalid number of affix rules %v", number+1, fields[3])
+				}
+				remaining = count
+				crossProduct = fields[2] == "Y"
+				continue
+			}
+
+			entry, err := parseAffixEntry(fields, crossProduct, number)
+			if err != nil {
+				return err
+			}
+			if entry.prefix {
+				hunspell.prefixes = append(hunspell.prefixes, entry)
+			} else {
+				hunspell.suffixes = append(hunspell.suffixes, entry)
+			}
+			hunspell.affixesByFlag[entry.flag] = append(hunspell.affixesByFlag[entry.flag], entry)
+			remaining--
+		}
+	}
+
+	return nil
+}
+
+// parseAffixEntry parses a rule of an affix class: type, flag, strip, add (with optional continuation
+// flags, ignored) and condition
+func parseAffixEntry(fields []string, crossProduct bool, number int) (*affixEntry, error) {
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("line %v: invalid affix rule", number+1)
+	}
+
+	strip := fields[2]
+	if strip == "0" {
+		strip = ""
+	}
+	add := fields[3]
+	if slash := strings.Index(add, "/"); slash >= 0 {
+		add = add[:slash]
+	}
+	if add == "0" {
+		add = ""
+	}
+	condition := "."
+	if len(fields) >= 5 {
+		condition = fields[4]
+	}
+
+	elements, err := parseCondition(condition)
+	if err != nil {
+		return nil, fmt.Errorf("line %v: %v", number+1, err)
+	}
+
+	return &affixEntry{
+		flag:         fields[1],
+		prefix:       fields[0] == "PFX",
+		crossProduct: crossProduct,
+		strip:        []rune(strip),
+		add:          []rune(add),
+		condition:    elements,
+	}, nil
+}
+
+// parseCondition parses the condition of an affix rule, made of runes, dots and classes such as [aeiou] or
+// [^aeiou]
+func parseCondition(condition string) ([]conditionElement, error) {
+	elements := make([]conditionElement, 0)
+	if condition == "." {
+		return elements, nil
+	}
+
+	runes := []rune(condition)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '.':
+			elements = append(elements, conditionElement{any: true})
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("condition %v has a class that is not closed", condition)
+			}
+			element := conditionElement{runes: runes[i+1 : end]}
+			if len(element.runes) > 0 && element.runes[0] == '^' {
+				element.negated = true
+				element.runes = element.runes[1:]
+			}
+			elements = append(elements, element)
+			i = end
+		default:
+			elements = append(elements, conditionElement{runes: []rune{runes[i]}})
+		}
+	}
+	return elements, nil
+}
+
+// readStems reads the .dic file: the number of stems, then a stem per line with its optional flags
+func (hunspell *HunspellDictionary) readStems(reader io.Reader) error {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	lines, err := hunspell.decodeLines(data)
+	if err != nil {
+		return err
+	}
+
+	for number, line := range lines {
+		fields := strings.Fields(line)
+		// The first line gives the approximate number of stems
+		if len(fields) == 0 || number == 0 {
+			continue
+		}
+
+		stem := fields[0]
+		flags := ""
+		if slash := strings.Index(stem, "/"); slash > 0 {
+			flags = stem[slash+1:]
+			stem = stem[:slash]
+		}
+
+		// With aliases, the flags are given by the number of their alias
+		if len(hunspell.flagAliases) > 0 && flags != "" {
+			alias, err := strconv.Atoi(flags)
+			if err != nil || alias < 1 || alias > len(hunspell.flagAliases) {
+				return fmt.Errorf("line %v: invalid flag alias %v", number+1, flags)
+			}
+			flags = hunspell.flagAliases[alias-1]
+		}
+
+		hunspell.Stems.Put(stem)
+		stemFlags := hunspell.flagsByStem[stem]
+		if stemFlags == nil {
+			stemFlags = make(map[string]bool)
+			hunspell.flagsByStem[stem] = stemFlags
+		}
+		for _, flag := range hunspell.splitFlags(flags) {
+			stemFlags[flag] = true
+		}
+	}
+
+	return nil
+}
+
+// decodeLines decodes the content of a file with the encoding of the dictionary and splits it into lines
+func (hunspell *HunspellDictionary) decodeLines(data []byte) ([]string, error) {
+	var text string
+	switch hunspell.encoding {
+	case "UTF-8":
+		text = string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	case "ISO8859-1", "ISO-8859-1":
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		text = string(runes)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %v", hunspell.encoding)
+	}
+
+	return strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n"), nil
+}
+
+// splitFlags splits the flags of a stem according to the flag type of the affix file
+func (hunspell *HunspellDictionary) splitFlags(flags string) []string {
+	result := make([]string, 0, utf8.RuneCountInString(flags))
+	switch hunspell.flagType {
+	case "long":
+		runes := []rune(flags)
+		for i := 0; i+1 < len(runes); i += 2 {
+			result = append(result, string(runes[i:i+2]))
+		}
+	case "num":
+		for _, flag := range strings.Split(flags, ",") {
+			if flag != "" {
+				result = append(result, flag)
+			}
+		}
+	default:
+		for _, flag := range flags {
+			result = append(result, string(flag))
+		}
+	}
+	return result
+}
+
+// Check returns true if the word is a stem of the dictionary or a stem with valid affixes
+func (hunspell *HunspellDictionary) Check(word string) bool {
+	return len(hunspell.GetStems(word)) > 0
+}
+
+// GetStems returns the stems of the dictionary from which the word can be derived
+func (hunspell *HunspellDictionary) GetStems(word string) []string {
+	stems := make([]string, 0)
+	found := make(map[string]bool)
+	for _, decomposition := range hunspell.decompose(word) {
+		if !found[decomposition.stem] && hunspell.Stems.Get(decomposition.stem) != nil && hunspell.accepts(decomposition.stem, decomposition) {
+			found[decomposition.stem] = true
+			stems = append(stems, decomposition.stem)
+		}
+	}
+	return stems
+}
+
+// SearchAll returns all the words, stems or inflected forms, having a Levenshtein distance lower or equal to
+// distanceMax with the searched term. The affixes are applied lazily: the term is decomposed into a stem and
+// affixes, the stems of the dictionary close to the stem of the term are searched and the affixes are applied
+// back to them. The affixes of the term must thus be spelled correctly, while the stem may be misspelled.
+//
+// The information returned for an inflected form is the one of its stem.
+func (hunspell *HunspellDictionary) SearchAll(searchedTerm string, distanceMax int) map[string]*WordInformation {
+	results := make(map[string]*WordInformation)
+	searched := make(map[string]map[string]*WordInformation)
+
+	for _, decomposition := range hunspell.decompose(searchedTerm) {
+		stems, done := searched[decomposition.stem]
+		if !done {
+			stems = hunspell.Stems.SearchAll(decomposition.stem, distanceMax)
+			searched[decomposition.stem] = stems
+		}
+
+		for stem, information := range stems {
+			if hunspell.accepts(stem, decomposition) {
+				results[hunspell.inflect(stem, decomposition)] = information
+			}
+		}
+	}
+
+	return results
+}
+
+// Expand returns a new dictionary holding all the stems and their inflected forms
+func (hunspell *HunspellDictionary) Expand() *Dictionary {
+	dictionary := CreateDictionary()
+	hunspell.ExpandInto(dictionary)
+	return dictionary
+}
+
+// ExpandInto puts all the stems and their inflected forms in the dictionary. Each form is put once per
+// stem it derives from.
+func (hunspell *HunspellDictionary) ExpandInto(dictionary *Dictionary) {
+	hunspell.Stems.Root.forEachWord("", func(stem string, information *WordInformation) {
+		if hunspell.isExcluded(stem) {
+			return
+		}
+
+		forms := make(map[string]bool)
+		if !hunspell.hasFlag(stem, hunspell.needAffix) {
+			forms[stem] = true
+		}
+
+		for flag := range hunspell.flagsByStem[stem] {
+			for _, entry := range hunspell.affixesByFlag[flag] {
+				if !entry.matchesCondition([]rune(stem)) {
+					continue
+				}
+				form := string(entry.apply([]rune(stem)))
+				forms[form] = true
+
+				// Combine the suffixes with the prefixes allowing it
+				if entry.prefix || !entry.crossProduct {
+					continue
+				}
+				for prefixFlag := range hunspell.flagsByStem[stem] {
+					for _, prefix := range hunspell.affixesByFlag[prefixFlag] {
+						if prefix.prefix && prefix.crossProduct && prefix.matchesCondition([]rune(form)) {
+							forms[string(prefix.apply([]rune(form)))] = true
+						}
+					}
+				}
+			}
+		}
+
+		for form := range forms {
+			dictionary.Put(form)
+		}
+	})
+}
+
+// decompose returns all the possible decompositions of the word into a stem and affixes, including the word
+// itself without affix. The stems are not checked against the dictionary.
+func (hunspell *HunspellDictionary) decompose(word string) []affixDecomposition {
+	runes := []rune(word)
+	decompositions := []affixDecomposition{{stem: word}}
+
+	suffixed := make([]affixDecomposition, 0)
+	for _, suffix := range hunspell.suffixes {
+		if stem, ok := suffix.remove(runes); ok {
+			suffixed = append(suffixed, affixDecomposition{stem: string(stem), suffix: suffix})
+		}
+	}
+	decompositions = append(decompositions, suffixed...)
+
+	for _, prefix := range hunspell.prefixes {
+		stem, ok := prefix.remove(runes)
+		if !ok {
+			continue
+		}
+		decompositions = append(decompositions, affixDecomposition{stem: string(stem), prefix: prefix})
+
+		if !prefix.crossProduct {
+			continue
+		}
+		for _, suffix := range hunspell.suffixes {
+			if !suffix.crossProduct {
+				continue
+			}
+			if root, ok := suffix.remove(stem); ok {
+				decompositions = append(decompositions, affixDecomposition{stem: string(root), prefix: prefix, suffix: suffix})
+			}
+		}
+	}
+
+	return decompositions
+}
+
+// accepts returns true if the stem has the flags of the affixes of the decomposition and fulfills their
+// conditions. As done by Hunspell, the condition of the prefix applies to the stem with its suffix
+func (hunspell *HunspellDictionary) accepts(stem string, decomposition affixDecomposition) bool {
+	flags := hunspell.flagsByStem[stem]
+	if hunspell.isExcluded(stem) {
+		return false
+	}
+	if decomposition.prefix == nil && decomposition.suffix == nil && hunspell.hasFlag(stem, hunspell.needAffix) {
+		return false
+	}
+
+	runes := []rune(stem)
+	if suffix := decomposition.suffix; suffix != nil {
+		if !flags[suffix.flag] || !suffix.matchesCondition(runes) {
+			return false
+		}
+		runes = suffix.apply(runes)
+	}
+	if prefix := decomposition.prefix; prefix != nil {
+		if !flags[prefix.flag] || !prefix.matchesCondition(runes) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasFlag returns true if the stem carries the flag of an option of the affix file, if it is defined
+func (hunspell *HunspellDictionary) hasFlag(stem string, flag string) bool {
+	return flag != "" && hunspell.flagsByStem[stem][flag]
+}
+
+// isExcluded returns true if neither the stem nor its inflected forms are words: the stem is forbidden or
+// only allowed inside compounds
+func (hunspell *HunspellDictionary) isExcluded(stem string) bool {
+	return hunspell.hasFlag(stem, hunspell.forbiddenWord) || hunspell.hasFlag(stem, hunspell.onlyInCompound)
+}
+
+// inflect applies the affixes of the decomposition to the stem
+func (hunspell *HunspellDictionary) inflect(stem string, decomposition affixDecomposition) string {
+	runes := []rune(stem)
+	if decomposition.suffix != nil {
+		runes = decomposition.suffix.apply(runes)
+	}
+	if decomposition.prefix != nil {
+		runes = decomposition.prefix.apply(runes)
+	}
+	return string(runes)
+}
+
+// apply applies the affix to the stem, which must fulfill the condition
+func (entry *affixEntry) apply(stem []rune) []rune {
+	result := make([]rune, 0, len(stem)+len(entry.add))
+	if entry.prefix {
+		result = append(result, entry.add...)
+		result = append(result, stem[min(len(entry.strip), len(stem)):]...)
+	} else {
+		result = append(result, stem[:len(stem)-min(len(entry.strip), len(stem))]...)
+		result = append(result, entry.add...)
+	}
+	return result
+}
+
+// remove removes the affix from the word, returning the stem and true if the word carries the affix
+func (entry *affixEntry) remove(word []rune) ([]rune, bool) {
+	if len(word) <= len(entry.add) {
+		return nil, false
+	}
+
+	stem := make([]rune, 0, len(word)-len(entry.add)+len(entry.strip))
+	if entry.prefix {
+		if !startsWith(word, entry.add) {
+			return nil, false
+		}
+		stem = append(stem, entry.strip...)
+		stem = append(stem, word[len(entry.add):]...)
+	} else {
+		if !endsWith(word, entry.add) {
+			return nil, false
+		}
+		stem = append(stem, word[:len(word)-len(entry.add)]...)
+		stem = append(stem, entry.strip...)
+	}
+
+	if !entry.matchesCondition(stem) {
+		return nil, false
+	}
+	return stem, true
+}
+
+// matchesCondition returns true if the stem carries the strip of the affix and fulfills its condition, at
+// its beginning for a prefix and at its end for a suffix
+func (entry *affixEntry) matchesCondition(stem []rune) bool {
+	if len(stem) < len(entry.condition) {
+		return false
+	}
+	if entry.prefix && !startsWith(stem, entry.strip) {
+		return false
+	}
+	if !entry.prefix && !endsWith(stem, entry.strip) {
+		return false
+	}
+
+	offset := 0
+	if !entry.prefix {
+		offset = len(stem) - len(entry.condition)
+	}
+	for i, element := range entry.condition {
+		if !element.matches(stem[offset+i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns true if the rune fulfills the element of the condition
+func (element conditionElement) matches(r rune) bool {
+	if element.any {
+		return true
+	}
+	for _, candidate := range element.runes {
+		if candidate == r {
+			return !element.negated
+		}
+	}
+	return element.negated
+}
+
+// startsWith returns true if the runes begin with the given prefix
+func startsWith(runes []rune, prefix []rune) bool {
+	if len(prefix) > len(runes) {
+		return false
+	}
+	for i, r := range prefix {
+		if runes[i] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,243 @@
+package levenshteinsearch
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testAffixes = `SET UTF-8
+# A reduced English affix file
+PFX A Y 1
+PFX A   0     re         .
+
+PFX U N 1
+PFX U   0     un         .
+
+SFX D Y 4
+SFX D   0     d          e
+SFX D   y     ied        [^aeiou]y
+SFX D   0     ed         [^ey]
+SFX D   0     ed         [aeiou]y
+
+SFX G Y 2
+SFX G   e     ing        e
+SFX G   0     ing        [^e]
+
+SFX S Y 2
+SFX S   y     ies        [^aeiou]y
+SFX S   0     s          [^y]
+`
+
+const testStems = `5
+walk/DGS
+create/ADGS
+carry/DS
+happy/U
+cat/S
+`
+
+func loadTestHunspell(t *testing.T) *HunspellDictionary {
+	hunspell, err := LoadHunspell(strings.NewReader(testStems), strings.NewReader(testAffixes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hunspell
+}
+
+func TestHunspellCheck(t *testing.T) {
+
+	hunspell := loadTestHunspell(t)
+
+	for _, word := range []string{"walk", "walked", "walking", "walks", "create", "created", "creating", "recreate", "recreated", "carried", "carries", "unhappy", "cats"} {
+		if !hunspell.Check(word) {
+			t.Errorf("Expected '%v' to be valid", word)
+		}
+	}
+	for _, word := range []string{"walkd", "createing", "carryed", "rewalk", "unhappys", "catting", "dog"} {
+		if hunspell.Check(word) {
+			t.Errorf("Expected '%v' to be invalid", word)
+		}
+	}
+
+	if stems := hunspell.GetStems("recreating"); len(stems) != 1 || stems[0] != "create" {
+		t.Errorf("Expected 'recreating' to derive from 'create', found %v", stems)
+	}
+}
+
+func TestHunspellExpand(t *testing.T) {
+
+	hunspell := loadTestHunspell(t)
+	dictionary := hunspell.Expand()
+
+	words := make([]string, 0)
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		words = append(words, word)
+	})
+	sort.Strings(words)
+
+	expected := []string{"carried", "carries", "carry", "cat", "cats", "create", "created", "creates", "creating", "happy", "recreate", "recreated", "recreates", "recreating", "unhappy", "walk", "walked", "walking", "walks"}
+	if strings.Join(words, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected expansion: %v", words)
+	}
+
+	// Every expanded form must be accepted by the lazy check
+	for _, word := range words {
+		if !hunspell.Check(word) {
+			t.Errorf("Expected the expanded form '%v' to be valid", word)
+		}
+	}
+}
+
+func TestHunspellSearchAll(t *testing.T) {
+
+	hunspell := loadTestHunspell(t)
+
+	results := hunspell.SearchAll("wlaking", 2)
+	if results["walking"] == nil {
+		t.Errorf("Expected 'walking' to be found for 'wlaking', found %v", results)
+	}
+
+	results = hunspell.SearchAll("recraeted", 2)
+	if results["recreated"] == nil {
+		t.Errorf("Expected 'recreated' to be found for 'recraeted', found %v", results)
+	}
+
+	results = hunspell.SearchAll("carri", 1)
+	if results["carry"] == nil || results["carries"] != nil {
+		t.Errorf("Unexpected results for 'carri': %v", results)
+	}
+
+	for word := range hunspell.SearchAll("cst", 1) {
+		if word != "cat" {
+			t.Errorf("Unexpected word '%v' for 'cst'", word)
+		}
+	}
+}
+
+func TestHunspellFlagTypes(t *testing.T) {
+
+	affixes := "FLAG long\nSFX Aa Y 1\nSFX Aa 0 s .\nSFX Bb Y 1\nSFX Bb 0 ed .\n"
+	hunspell, err := LoadHunspell(strings.NewReader("1\njump/AaBb\n"), strings.NewReader(affixes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hunspell.Check("jumps") || !hunspell.Check("jumped") {
+		t.Error("Expected the long flags to be used")
+	}
+
+	affixes = "FLAG num\nSFX 101 Y 1\nSFX 101 0 s .\n"
+	hunspell, err = LoadHunspell(strings.NewReader("1\njump/7,101\n"), strings.NewReader(affixes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hunspell.Check("jumps") {
+		t.Error("Expected the numeric flags to be used")
+	}
+
+	hunspell, err = LoadHunspell(strings.NewReader("1\ncaf\xe9\n"), strings.NewReader("SET ISO8859-1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hunspell.Check("café") {
+		t.Error("Expected the ISO8859-1 file to be decoded")
+	}
+
+	if _, err = LoadHunspell(strings.NewReader("0\n"), strings.NewReader("SET KOI8-R\n")); err == nil {
+		t.Error("Expected an unsupported encoding to be reported")
+	}
+	if _, err = LoadHunspell(strings.NewReader("0\n"), strings.NewReader("SFX A Y 1\nSFX A 0 s [ab\n")); err == nil {
+		t.Error("Expected an invalid condition to be reported")
+	}
+}
+
+func TestHunspellFlagAliases(t *testing.T) {
+
+	affixes := "AF 2\nAF S # cat\nAF DS\nSFX S Y 1\nSFX S 0 s .\nSFX D Y 1\nSFX D 0 ed .\n"
+	hunspell, err := LoadHunspell(strings.NewReader("3\ncat/1\njump/2\ndog\n"), strings.NewReader(affixes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, word := range []string{"cat", "cats", "jumps", "jumped", "dog"} {
+		if !hunspell.Check(word) {
+			t.Errorf("Expected '%v' to be valid", word)
+		}
+	}
+	if hunspell.Check("cated") || hunspell.Check("dogs") {
+		t.Error("Expected the aliases to only give their own flags")
+	}
+
+	if _, err = LoadHunspell(strings.NewReader("1\ncat/3\n"), strings.NewReader(affixes)); err == nil {
+		t.Error("Expected an unknown alias to be reported")
+	}
+}
+
+func TestHunspellNeedAffix(t *testing.T) {
+
+	affixes := "NEEDAFFIX X\nSFX S Y 1\nSFX S 0 s .\n"
+	hunspell, err := LoadHunspell(strings.NewReader("2\nscissor/XS\ncat/S\n"), strings.NewReader(affixes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hunspell.Check("scissor") || !hunspell.Check("scissors") || !hunspell.Check("cat") {
+		t.Error("Expected 'scissor' to need an affix")
+	}
+	if results := hunspell.SearchAll("scisor", 1); len(results) != 0 {
+		t.Errorf("Expected no word for 'scisor', found %v", results)
+	}
+
+	dict := hunspell.Expand()
+	if dict.Get("scissor") != nil || dict.Get("scissors") == nil {
+		t.Error("Expected the expansion to only hold the affixed form of 'scissor'")
+	}
+}
+
+func TestHunspellUnsupportedOptions(t *testing.T) {
+
+	if _, err := LoadHunspell(strings.NewReader("1\ncat\n"), strings.NewReader("FLAG UTF-16\n")); err == nil {
+		t.Error("Expected an unknown flag type to be reported as unsupported")
+	}
+
+	if _, err := LoadHunspell(strings.NewReader("1\ncat\n"), strings.NewReader("FLAG UTF-8\nKEEPCASE K\n")); err != nil {
+		t.Errorf("Expected the UTF-8 flags and the other options to be accepted, found %v", err)
+	}
+}
+
+func TestHunspellCompoundAndForbiddenStems(t *testing.T) {
+
+	// As in en_US.aff, the compounding options are skipped
+	affixes := `COMPOUNDMIN 1
+ONLYINCOMPOUND c
+COMPOUNDRULE 2
+COMPOUNDRULE n*1t
+COMPOUNDRULE n*mp
+COMPOUNDFLAG Y
+FORBIDDENWORD !
+SFX S Y 1
+SFX S 0 s .
+`
+	hunspell, err := LoadHunspell(strings.NewReader("4\n1th/tc\ncat/SY\nfoo/S!\ndog/S\n"), strings.NewReader(affixes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range []string{"cat", "cats", "dog", "dogs"} {
+		if !hunspell.Check(word) {
+			t.Errorf("Expected '%v' to be valid", word)
+		}
+	}
+	for _, word := range []string{"1th", "foo", "foos", "catdog"} {
+		if hunspell.Check(word) {
+			t.Errorf("Expected '%v' to be invalid", word)
+		}
+	}
+
+	if results := hunspell.SearchAll("fo", 1); len(results) != 0 {
+		t.Errorf("Expected no word close to 'fo', found %v", results)
+	}
+
+	dict := hunspell.Expand()
+	if dict.UniqueWordCount != 4 || dict.Get("foo") != nil || dict.Get("1th") != nil {
+		t.Errorf("Expected the expansion to only hold the allowed forms, found %v", dict.getAllWords())
+	}
+}
@@ -0,0 +1,134 @@
+package levenshteinsearch
+
+// JoinPair is a pair of words, one from each dictionary, found by FuzzyJoin
+type JoinPair struct {
+	// First is the word of the first dictionary
+	First string
+	// FirstInformation is the information of the word in the first dictionary
+	FirstInformation *WordInformation
+	// Second is the word of the second dictionary
+	Second string
+	// SecondInformation is the information of the word in the second dictionary
+	SecondInformation *WordInformation
+	// Distance is the Levenshtein distance between the two words
+	Distance int
+}
+
+// activeNode is a node of the second trie whose word is close to the current word of the first trie
+type activeNode struct {
+	word     string
+	distance int
+}
+
+// activeNodes holds, for a node of the first trie, all the nodes of the second trie at a distance lower or
+// equal to the maximum
+type activeNodes map[*RuneTrie]activeNode
+
+// FuzzyJoin calls visit for each pair of words, one from each dictionary, having a Levenshtein distance lower
+// or equal to distanceMax, until visit returns false. The pairs come in no particular order.
+//
+// Both tries are walked at once: each node of the first trie keeps the nodes of the second trie close to
+// it, computed from the ones of its parent. A branch of the first trie is cut, along with all its pairs, as
+// soon as no node of the second trie is close enough.
+func FuzzyJoin(first *Dictionary, second *Dictionary, distanceMax int, visit func(pair JoinPair) bool) {
+	if distanceMax < 0 {
+		return
+	}
+
+	// The nodes close to the empty word are the ones not deeper than the maximum distance
+	active := make(activeNodes)
+	active.relax(&second.Root, "", 0, distanceMax)
+	active.addInsertions(distanceMax)
+
+	first.Root.fuzzyJoin("", active, distanceMax, visit)
+}
+
+// fuzzyJoin is the recursive part of FuzzyJoin. It returns false once the join is stopped
+func (trie *RuneTrie) fuzzyJoin(prefix string, active activeNodes, distanceMax int, visit func(pair JoinPair) bool) bool {
+	if trie.information != nil {
+		for node, entry := range active {
+			if node.information == nil {
+				continue
+			}
+			pair := JoinPair{
+				First:             prefix,
+				FirstInformation:  trie.information,
+				Second:            entry.word,
+				SecondInformation: node.information,
+				Distance:          entry.distance,
+			}
+			if !visit(pair) {
+				return false
+			}
+		}
+	}
+
+	for character, child := range trie.children {
+		childActive := active.step(character, distanceMax)
+		if len(childActive) == 0 {
+			continue
+		}
+		if !child.fuzzyJoin(prefix+string(character), childActive, distanceMax, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// step returns the nodes close to the current word of the first trie followed by the character
+func (active activeNodes) step(character rune, distanceMax int) activeNodes {
+	next := make(activeNodes)
+	for node, entry := range active {
+		// The character is deleted
+		next.relax(node, entry.word, entry.distance+1, distanceMax)
+
+		// The character is matched or substituted
+		for childCharacter, child := range node.children {
+			cost := 1
+			if childCharacter == character {
+				cost = 0
+			}
+			next.relax(child, entry.word+string(childCharacter), entry.distance+cost, distanceMax)
+		}
+	}
+	next.addInsertions(distanceMax)
+	return next
+}
+
+// addInsertions adds the descendants of the nodes reached by inserting runes. The nodes are processed by
+// increasing distance, so that each one gets its lowest distance
+func (active activeNodes) addInsertions(distanceMax int) {
+	buckets := make([][]*RuneTrie, distanceMax+1)
+	for node, entry := range active {
+		buckets[entry.distance] = append(buckets[entry.distance], node)
+	}
+
+	for distance := 0; distance < distanceMax; distance++ {
+		for i := 0; i < len(buckets[distance]); i++ {
+			node := buckets[distance][i]
+			entry := active[node]
+			// The node was reached again with a lower distance
+			if entry.distance != distance {
+				continue
+			}
+			for childCharacter, child := range node.children {
+				if active.relax(child, entry.word+string(childCharacter), distance+1, distanceMax) {
+					buckets[distance+1] = append(buckets[distance+1], child)
+				}
+			}
+		}
+	}
+}
+
+// relax keeps the node at the given distance if it is lower than both its current one and the maximum. It
+// returns true if the node was kept
+func (active activeNodes) relax(node *RuneTrie, word string, distance int, distanceMax int) bool {
+	if distance > distanceMax {
+		return false
+	}
+	if entry, found := active[node]; found && entry.distance <= distance {
+		return false
+	}
+	active[node] = activeNode{word: word, distance: distance}
+	return true
+}
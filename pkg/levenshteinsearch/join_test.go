@@ -0,0 +1,94 @@
+package levenshteinsearch
+
+import (
+	"testing"
+)
+
+func TestFuzzyJoin(t *testing.T) {
+
+	first := CreateDictionary()
+	for _, word := range []string{"rabbit", "queen", "hatter", ""} {
+		first.Put(word)
+	}
+	second := CreateDictionary()
+	for _, word := range []string{"rabit", "rabbits", "habit", "quean", "hater", "a"} {
+		second.Put(word)
+	}
+
+	pairs := map[string]int{}
+	FuzzyJoin(first, second, 1, func(pair JoinPair) bool {
+		pairs[pair.First+"/"+pair.Second] = pair.Distance
+		if first.Get(pair.First) != pair.FirstInformation || second.Get(pair.Second) != pair.SecondInformation {
+			t.Errorf("Unexpected information for %v/%v", pair.First, pair.Second)
+		}
+		return true
+	})
+
+	expected := map[string]int{"rabbit/rabit": 1, "rabbit/rabbits": 1, "queen/quean": 1, "hatter/hater": 1, "/a": 1}
+	if len(pairs) != len(expected) {
+		t.Errorf("Expected %v pairs, found %v", expected, pairs)
+	}
+	for pair, distance := range expected {
+		if found, ok := pairs[pair]; !ok || found != distance {
+			t.Errorf("Expected the pair %v at distance %v, found %v", pair, distance, found)
+		}
+	}
+
+	// The join stops when requested
+	count := 0
+	FuzzyJoin(first, second, 1, func(pair JoinPair) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("Expected the join to stop after 2 pairs, found %v", count)
+	}
+}
+
+func TestFuzzyJoinMatchesBruteForce(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	first := CreateDictionary()
+	second := CreateDictionary()
+	for _, word := range aliceWords {
+		first.Put(word)
+		second.Put(word)
+	}
+	first.Prune(10)
+	second.Prune(3)
+
+	firstWords := first.getAllWords()
+	secondWords := second.getAllWords()
+	for distanceMax := 0; distanceMax < 3; distanceMax++ {
+		expected := map[[2]string]int{}
+		for firstWord := range firstWords {
+			for secondWord := range secondWords {
+				if distance := levenshtein([]rune(firstWord), []rune(secondWord)); distance <= distanceMax {
+					expected[[2]string{firstWord, secondWord}] = distance
+				}
+			}
+		}
+
+		found := map[[2]string]int{}
+		FuzzyJoin(first, second, distanceMax, func(pair JoinPair) bool {
+			key := [2]string{pair.First, pair.Second}
+			if _, duplicate := found[key]; duplicate {
+				t.Errorf("Duplicate pair %v", key)
+			}
+			found[key] = pair.Distance
+			return true
+		})
+
+		if len(found) != len(expected) {
+			t.Errorf("Distance %v: expected %v pairs, found %v", distanceMax, len(expected), len(found))
+		}
+		for key, distance := range found {
+			if expectedDistance, ok := expected[key]; !ok || expectedDistance != distance {
+				t.Errorf("Distance %v: unexpected pair %v at distance %v", distanceMax, key, distance)
+			}
+		}
+	}
+}
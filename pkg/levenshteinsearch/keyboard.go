@@ -0,0 +1,111 @@
+package levenshteinsearch
+
+import (
+	"unicode"
+)
+
+// KeyboardLayout gives the position of the keys of a keyboard. When used in a search, the substitution of a
+// rune by the rune of an adjacent key, a frequent typo, costs AdjacentKeyCost instead of 1.
+type KeyboardLayout struct {
+	// Name is the name of the layout
+	Name string
+	// AdjacentKeyCost is the cost of the substitution of a rune by the rune of an adjacent key
+	AdjacentKeyCost float64
+	positions       map[rune]keyPosition
+}
+
+// keyPosition is the position of the center of a key, in width of key
+type keyPosition struct {
+	row    int
+	column float64
+}
+
+// defaultAdjacentKeyCost is the cost of the substitution of adjacent keys in the predefined layouts
+const defaultAdjacentKeyCost = 0.5
+
+// CreateKeyboardLayout creates a new layout from the rows of keys, from top to bottom. The offsets give the
+// horizontal position of the first key of each row, in width of key, accounting for the stagger of the
+// rows. The letters are registered both in lower and upper case.
+func CreateKeyboardLayout(name string, rows []string, offsets []float64) *KeyboardLayout {
+	positions := make(map[rune]keyPosition)
+	for row, keys := range rows {
+		column := offsets[row]
+		for _, key := range keys {
+			position := keyPosition{row: row, column: column}
+			positions[key] = position
+			positions[unicode.ToUpper(key)] = position
+			column++
+		}
+	}
+
+	return &KeyboardLayout{
+		Name:            name,
+		AdjacentKeyCost: defaultAdjacentKeyCost,
+		positions:       positions,
+	}
+}
+
+// CreateQwertyLayout creates the US QWERTY layout
+func CreateQwertyLayout() *KeyboardLayout {
+	return CreateKeyboardLayout(
+		"QWERTY",
+		[]string{"1234567890-=", "qwertyuiop[]", "asdfghjkl;'", "zxcvbnm,./"},
+		[]float64{1, 1.5, 1.75, 2.25})
+}
+
+// CreateAzertyLayout creates the French AZERTY layout
+func CreateAzertyLayout() *KeyboardLayout {
+	return CreateKeyboardLayout(
+		"AZERTY",
+		[]string{"&é\"'(-è_çà)=", "azertyuiop^$", "qsdfghjklmù*", "<wxcvbn,;:!"},
+		[]float64{1, 1.5, 1.75, 1.25})
+}
+
+// CreateQwertzLayout creates the German QWERTZ layout
+func CreateQwertzLayout() *KeyboardLayout {
+	return CreateKeyboardLayout(
+		"QWERTZ",
+		[]string{"1234567890ß", "qwertzuiopü+", "asdfghjklöä#", "<yxcvbnm,.-"},
+		[]float64{1, 1.5, 1.75, 1.25})
+}
+
+// AreAdjacent returns true if the keys of the two runes are next to each other, on the same row or on
+// adjacent rows. A rune is not adjacent to itself.
+func (layout *KeyboardLayout) AreAdjacent(first rune, second rune) bool {
+	firstPosition, found := layout.positions[first]
+	if !found {
+		return false
+	}
+	secondPosition, found := layout.positions[second]
+	if !found {
+		return false
+	}
+
+	rowDistance := firstPosition.row - secondPosition.row
+	columnDistance := firstPosition.column - secondPosition.column
+	if columnDistance < 0 {
+		columnDistance = -columnDistance
+	}
+
+	switch rowDistance {
+	case 0:
+		return columnDistance == 1
+	case -1, 1:
+		// Due to the stagger, a key touches the two or three keys of the adjacent rows whose centers are
+		// less than a key apart
+		return columnDistance < 1
+	default:
+		return false
+	}
+}
+
+// GetSubstitutionCost returns the cost of replacing the rune of the searched term by the rune of the word
+func (layout *KeyboardLayout) GetSubstitutionCost(termRune rune, wordRune rune) float64 {
+	if termRune == wordRune {
+		return 0
+	}
+	if layout.AreAdjacent(termRune, wordRune) {
+		return layout.AdjacentKeyCost
+	}
+	return 1
+}
@@ -0,0 +1,68 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestKeyboardAdjacency(t *testing.T) {
+
+	qwerty := CreateQwertyLayout()
+
+	for _, neighbour := range "weadzx" {
+		if !qwerty.AreAdjacent('s', neighbour) {
+			t.Errorf("Expected 's' and '%c' to be adjacent on QWERTY", neighbour)
+		}
+	}
+	for _, far := range "sqrcf" {
+		if qwerty.AreAdjacent('s', far) {
+			t.Errorf("Expected 's' and '%c' to not be adjacent on QWERTY", far)
+		}
+	}
+	if !qwerty.AreAdjacent('S', 'a') {
+		t.Error("Expected the case to be ignored")
+	}
+	if qwerty.AreAdjacent('s', '€') {
+		t.Error("Expected an unknown key to be adjacent to nothing")
+	}
+
+	azerty := CreateAzertyLayout()
+	if !azerty.AreAdjacent('a', 'z') || !azerty.AreAdjacent('q', 'w') || azerty.AreAdjacent('a', 's') {
+		t.Error("Expected the adjacency of the AZERTY layout")
+	}
+
+	qwertz := CreateQwertzLayout()
+	if !qwertz.AreAdjacent('t', 'z') || !qwertz.AreAdjacent('a', 'y') || qwertz.AreAdjacent('t', 'y') {
+		t.Error("Expected the adjacency of the QWERTZ layout")
+	}
+}
+
+func TestSearchWithLayout(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("rubbit")
+	dict.Put("rubbit")
+	dict.Put("rabbit")
+	dict.Put("habit")
+
+	// Without layout, the most frequent word comes first
+	results := dict.SearchWithOptions("rsbbit", SearchOptions{DistanceMax: 3})
+	if len(results) != 3 || results[0].Word != "rubbit" {
+		t.Fatalf("Expected 'rubbit' first without layout, found %v", results)
+	}
+
+	results = dict.SearchWithOptions("rsbbit", SearchOptions{DistanceMax: 3, Layout: CreateQwertyLayout(), WithEditScript: true})
+	if len(results) != 3 || results[0].Word != "rabbit" || results[2].Word != "habit" {
+		t.Fatalf("Expected 'rabbit' first and 'habit' last with the QWERTY layout, found %v", results)
+	}
+	if results[0].Cost != 0.5 || results[0].Distance != 1 {
+		t.Errorf("Expected 'rabbit' to have a cost of 0.5 for a single edit, found %v", results[0])
+	}
+	if GetEditDistance(results[0].EditScript) != 1 || results[0].EditScript[1].Type != EditSubstitute {
+		t.Errorf("Expected the edit script of 'rabbit' to have a single substitution, found %v", results[0].EditScript)
+	}
+
+	// The maximum distance bounds the cost: two substitutions of adjacent keys fit in 1
+	results = dict.SearchWithOptions("rsbbiy", SearchOptions{DistanceMax: 1, Layout: CreateQwertyLayout()})
+	if len(results) != 1 || results[0].Word != "rabbit" || results[0].Distance != 2 {
+		t.Errorf("Expected to find 'rabbit' with two edits, found %v", results)
+	}
+}
@@ -0,0 +1,237 @@
+package levenshteinsearch
+
+import (
+	"math"
+)
+
+// DistanceMetric is the distance used to compare the searched term with the words of the dictionary
+type DistanceMetric int
+
+const (
+	// MetricLevenshtein counts the insertions, deletions and substitutions
+	MetricLevenshtein DistanceMetric = iota
+	// MetricHamming only counts the substitutions, so that only the words having the length of the searched
+	// term are found. It suits fixed length codes, such as postal codes or hashes
+	MetricHamming
+	// MetricLCS only counts the insertions and deletions, a substitution costing two edits. It is the
+	// distance derived from the longest common subsequence
+	MetricLCS
+)
+
+// String returns the name of the metric
+func (metric DistanceMetric) String() string {
+	switch metric {
+	case MetricLevenshtein:
+		return "Levenshtein"
+	case MetricHamming:
+		return "Hamming"
+	case MetricLCS:
+		return "LCS"
+	default:
+		return "unknown"
+	}
+}
+
+// HammingAutomaton is an automaton matching the words having the length of the searched term and differing
+// from it by at most distanceMax substitutions
+type HammingAutomaton struct {
+	distanceMax       int
+	searchedTermRunes []rune
+}
+
+// hammingState is a state of the HammingAutomaton: the number of runes read and the number of them that
+// differ from the searched term
+type hammingState struct {
+	position   int
+	mismatches int
+}
+
+// CreateHammingAutomaton creates a new automaton for the Hamming distance
+func CreateHammingAutomaton(searchedTerm string, distanceMax int) *HammingAutomaton {
+	return &HammingAutomaton{
+		distanceMax:       distanceMax,
+		searchedTermRunes: []rune(searchedTerm),
+	}
+}
+
+// GetDistanceMax returns the maximum distance defined for this automaton
+func (automaton *HammingAutomaton) GetDistanceMax() int {
+	return automaton.distanceMax
+}
+
+// Start gives the initial state allowing to step into the automaton
+func (automaton *HammingAutomaton) Start() State {
+	return hammingState{position: 0, mismatches: 0}
+}
+
+// Step steps through the automaton by generating the next state based on the current one + the given
+// char.
+func (automaton *HammingAutomaton) Step(genericState State, character rune) State {
+	state := genericState.(hammingState)
+
+	// A word longer than the searched term can never match
+	if state.position >= len(automaton.searchedTermRunes) {
+		return hammingState{position: state.position + 1, mismatches: automaton.distanceMax + 1}
+	}
+
+	mismatches := state.mismatches
+	if automaton.searchedTermRunes[state.position] != character {
+		mismatches++
+	}
+
+	return hammingState{position: state.position + 1, mismatches: mismatches}
+}
+
+// IsMatch returns true if the given states is matching
+func (automaton *HammingAutomaton) IsMatch(genericState State) bool {
+	state := genericState.(hammingState)
+	return state.position == len(automaton.searchedTermRunes) && state.mismatches <= automaton.distanceMax
+}
+
+// CanMatch returns true if the given states can match
+func (automaton *HammingAutomaton) CanMatch(genericState State) bool {
+	state := genericState.(hammingState)
+	return state.position <= len(automaton.searchedTermRunes) && state.mismatches <= automaton.distanceMax
+}
+
+// getCost returns the cost and the number of edits of a matching state, both being the Hamming distance
+func (automaton *HammingAutomaton) getCost(genericState State) (float64, int) {
+	state := genericState.(hammingState)
+	return float64(state.mismatches), state.mismatches
+}
+
+// LCSAutomaton is an automaton matching the words that can be obtained from the searched term with at most
+// distanceMax insertions and deletions. It uses the same sparse states as the LevenshteinAutomaton.
+type LCSAutomaton struct {
+	distanceMax       int
+	searchedTermRunes []rune
+}
+
+// CreateLCSAutomaton creates a new automaton for the insertion and deletion distance
+func CreateLCSAutomaton(searchedTerm string, distanceMax int) *LCSAutomaton {
+	return &LCSAutomaton{
+		distanceMax:       distanceMax,
+		searchedTermRunes: []rune(searchedTerm),
+	}
+}
+
+// GetDistanceMax returns the maximum distance defined for this automaton
+func (automaton *LCSAutomaton) GetDistanceMax() int {
+	return automaton.distanceMax
+}
+
+// Start gives the initial state allowing to step into the automaton
+func (automaton *LCSAutomaton) Start() State {
+	length := min(automaton.distanceMax, len(automaton.searchedTermRunes)) + 1
+	indices := make([]int, length)
+	values := make([]int, length)
+	for i := 0; i < length; i++ {
+		indices[i] = i
+		values[i] = i
+	}
+
+	return AutomatonState{
+		indices: indices,
+		values:  values,
+	}
+}
+
+// Step steps through the automaton by generating the next state based on the current one + the given
+// char.
+func (automaton *LCSAutomaton) Step(genericState State, character rune) State {
+	state := genericState.(AutomatonState)
+
+	var newIndices []int
+	var newValues []int
+
+	if (len(state.indices) > 0) && (state.indices[0] == 0) && (state.values[0] < automaton.distanceMax) {
+		newIndices = []int{0}
+		newValues = []int{state.values[0] + 1}
+	} else {
+		newIndices = []int{}
+		newValues = []int{}
+	}
+
+	for counter, value := range state.indices {
+		if value == len(automaton.searchedTermRunes) {
+			break
+		}
+
+		// Without substitution, the diagonal is only possible for identical runes
+		val := automaton.distanceMax + 1
+		if automaton.searchedTermRunes[value] == character {
+			val = state.values[counter]
+		}
+
+		if (len(newIndices) > 0) && (newIndices[len(newIndices)-1] == value) {
+			val = min(val, newValues[len(newValues)-1]+1)
+		}
+		if ((counter + 1) < len(state.indices)) && (state.indices[counter+1] == value+1) {
+			val = min(val, state.values[counter+1]+1)
+		}
+		if val <= automaton.distanceMax {
+			newIndices = append(newIndices, value+1)
+			newValues = append(newValues, val)
+		}
+	}
+
+	return AutomatonState{
+		indices: newIndices,
+		values:  newValues,
+	}
+}
+
+// IsMatch returns true if the given states is matching
+func (automaton *LCSAutomaton) IsMatch(genericState State) bool {
+	state := genericState.(AutomatonState)
+	return (len(state.indices) > 0) && (state.indices[len(state.indices)-1] == len(automaton.searchedTermRunes))
+}
+
+// CanMatch returns true if the given states can match
+func (automaton *LCSAutomaton) CanMatch(genericState State) bool {
+	state := genericState.(AutomatonState)
+	return len(state.indices) > 0
+}
+
+// getCost returns the cost and the number of edits of a matching state, both being the insertion and
+// deletion distance
+func (automaton *LCSAutomaton) getCost(genericState State) (float64, int) {
+	state := genericState.(AutomatonState)
+	distance := state.values[len(state.values)-1]
+	return float64(distance), distance
+}
+
+// SearchAllHamming returns all the words of the dictionary having a Hamming distance lower or equal to
+// distanceMax with the searched term
+func (dictionary *Dictionary) SearchAllHamming(searchedTerm string, distanceMax int) map[string]*WordInformation {
+	return dictionary.Search(CreateHammingAutomaton(searchedTerm, distanceMax))
+}
+
+// SearchAllLCS returns all the words of the dictionary that can be obtained from the searched term with at
+// most distanceMax insertions and deletions
+func (dictionary *Dictionary) SearchAllLCS(searchedTerm string, distanceMax int) map[string]*WordInformation {
+	return dictionary.Search(CreateLCSAutomaton(searchedTerm, distanceMax))
+}
+
+// forbiddenSubstitutionCost forbids the substitutions of different runes, as done by the LCS distance
+func forbiddenSubstitutionCost(termRune rune, wordRune rune) float64 {
+	if termRune == wordRune {
+		return 0
+	}
+	return math.Inf(1)
+}
+
+// computeHammingEditScript returns the edit script between the query and a word of the same length, made
+// only of matches and substitutions
+func computeHammingEditScript(queryRunes []rune, wordRunes []rune, substitutionCost SubstitutionCost) []EditOperation {
+	operations := make([]EditOperation, 0, len(queryRunes))
+	for i := 0; i < len(queryRunes) && i < len(wordRunes); i++ {
+		cost := substitutionCost(queryRunes[i], wordRunes[i])
+		operationType := EditSubstitute
+		if cost == 0 {
+			operationType = EditMatch
+		}
+		operations = append(operations, EditOperation{Type: operationType, QueryPosition: i, WordPosition: i, QueryRune: queryRunes[i], WordRune: wordRunes[i], QueryLength: 1, WordLength: 1, Cost: cost})
+	}
+	return operations
+}
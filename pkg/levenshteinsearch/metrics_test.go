@@ -0,0 +1,139 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestSearchAllHammingMatchesBruteForce(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	data := map[string]*WordInformation{}
+	dict.Root.forEachWord("", func(word string, information *WordInformation) {
+		data[word] = information
+	})
+
+	for _, term := range []string{"rabbit", "alice", "the", "queen", "a", ""} {
+		for distanceMax := 0; distanceMax < 4; distanceMax++ {
+			expected := map[string]bool{}
+			for word := range data {
+				if distance, ok := hamming([]rune(term), []rune(word)); ok && distance <= distanceMax {
+					expected[word] = true
+				}
+			}
+			compareResults(t, "Hamming", term, distanceMax, expected, dict.SearchAllHamming(term, distanceMax))
+		}
+	}
+}
+
+func TestSearchAllLCSMatchesBruteForce(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	data := map[string]*WordInformation{}
+	dict.Root.forEachWord("", func(word string, information *WordInformation) {
+		data[word] = information
+	})
+
+	for _, term := range []string{"rabbit", "alice", "the", "queen", "a", ""} {
+		for distanceMax := 0; distanceMax < 4; distanceMax++ {
+			expected := map[string]bool{}
+			for word := range data {
+				if lcsDistance([]rune(term), []rune(word)) <= distanceMax {
+					expected[word] = true
+				}
+			}
+			compareResults(t, "LCS", term, distanceMax, expected, dict.SearchAllLCS(term, distanceMax))
+		}
+	}
+}
+
+func TestSearchWithOptionsMetric(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("75001")
+	dict.Put("75010")
+	dict.Put("7501")
+	dict.Put("750012")
+
+	results := dict.SearchWithOptions("75001", SearchOptions{DistanceMax: 2, Metric: MetricHamming, WithEditScript: true})
+	if len(results) != 2 || results[0].Word != "75001" || results[1].Word != "75010" || results[1].Distance != 2 {
+		t.Fatalf("Unexpected Hamming results: %v", results)
+	}
+	for _, operation := range results[1].EditScript {
+		if operation.Type != EditMatch && operation.Type != EditSubstitute {
+			t.Errorf("Expected a Hamming edit script to only hold matches and substitutions, found %v", operation.Type)
+		}
+	}
+
+	results = dict.SearchWithOptions("75001", SearchOptions{DistanceMax: 1, Metric: MetricLCS, WithEditScript: true})
+	if len(results) != 3 || results[0].Word != "75001" {
+		t.Fatalf("Unexpected LCS results: %v", results)
+	}
+
+	results = dict.SearchWithOptions("75001", SearchOptions{DistanceMax: 2, Metric: MetricLCS, WithEditScript: true})
+	for _, result := range results {
+		if GetEditDistance(result.EditScript) != result.Distance {
+			t.Errorf("Expected the edit script of '%v' to have %v edits", result.Word, result.Distance)
+		}
+		for _, operation := range result.EditScript {
+			if operation.Type == EditSubstitute {
+				t.Errorf("Expected a LCS edit script to hold no substitution")
+			}
+		}
+	}
+}
+
+func compareResults(t *testing.T, metric string, term string, distanceMax int, expected map[string]bool, results map[string]*WordInformation) {
+	if len(results) != len(expected) {
+		t.Errorf("%v search of '%v' with a distance of %v: expected %v words, found %v", metric, term, distanceMax, len(expected), len(results))
+	}
+	for word := range results {
+		if !expected[word] {
+			t.Errorf("%v search of '%v' with a distance of %v: unexpected word '%v'", metric, term, distanceMax, word)
+		}
+	}
+}
+
+// hamming returns the Hamming distance of two strings, and false if their lengths differ
+func hamming(str1, str2 []rune) (int, bool) {
+	if len(str1) != len(str2) {
+		return 0, false
+	}
+	distance := 0
+	for i := range str1 {
+		if str1[i] != str2[i] {
+			distance++
+		}
+	}
+	return distance, true
+}
+
+// lcsDistance returns the number of insertions and deletions between two strings, computed from the length
+// of their longest common subsequence
+func lcsDistance(str1, str2 []rune) int {
+	lengths := make([][]int, len(str1)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(str2)+1)
+	}
+	for i := 1; i <= len(str1); i++ {
+		for j := 1; j <= len(str2); j++ {
+			if str1[i-1] == str2[j-1] {
+				lengths[i][j] = lengths[i-1][j-1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i-1][j], lengths[i][j-1])
+			}
+		}
+	}
+	return len(str1) + len(str2) - 2*lengths[len(str1)][len(str2)]
+}
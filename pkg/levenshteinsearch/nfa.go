@@ -0,0 +1,172 @@
+package levenshteinsearch
+
+// emptyCondition is the condition that must be fulfilled for following the empty transitions of a node
+type emptyCondition int
+
+const (
+	// conditionNone means that the empty transitions can always be followed
+	conditionNone emptyCondition = iota
+	// conditionBegin means that the empty transitions can only be followed before the first rune
+	conditionBegin
+	// conditionEnd means that the empty transitions can only be followed after the last rune
+	conditionEnd
+)
+
+// nfaNode is a single node of a non deterministic finite automaton. A node either consumes a rune accepted
+// by the accept function and goes to next, or is only linked to other nodes by empty transitions.
+type nfaNode struct {
+	accept    func(character rune) bool
+	next      int
+	epsilons  []int
+	condition emptyCondition
+	final     bool
+}
+
+// fuzzyNFA is a non deterministic finite automaton that tolerates a number of edits (insertion, deletion
+// or substitution of a rune) between the matched words and the language it recognizes. It is the common
+// engine of the automata that are not defined by a single searched term, such as patterns or regular
+// expressions.
+type fuzzyNFA struct {
+	nodes       []nfaNode
+	startNode   int
+	distanceMax int
+}
+
+// nfaState is a state of the fuzzyNFA. As for the AutomatonState, only the active nodes are kept along
+// with the minimal number of edits needed to reach them. The nodes are sorted.
+type nfaState struct {
+	nodes []int
+	costs []int
+}
+
+// noCost is the cost of a node that is not part of a state
+const noCost = int(^uint(0) >> 1)
+
+// start gives the initial state of the automaton
+func (nfa *fuzzyNFA) start() nfaState {
+	costs := nfa.newCosts()
+	costs[nfa.startNode] = 0
+	nfa.closure(costs, conditionBegin)
+	return nfa.compact(costs)
+}
+
+// step generates the next state based on the current one + the given char
+func (nfa *fuzzyNFA) step(state nfaState, character rune) nfaState {
+	costs := nfa.newCosts()
+
+	for counter, nodeIndex := range state.nodes {
+		cost := state.costs[counter]
+		node := &nfa.nodes[nodeIndex]
+
+		// Consume the character, either as is or by a substitution
+		if node.accept != nil {
+			if node.accept(character) {
+				relax(costs, node.next, cost)
+			} else {
+				relax(costs, node.next, cost+1)
+			}
+		}
+
+		// Insertion of the character in the word
+		relax(costs, nodeIndex, cost+1)
+	}
+
+	nfa.closure(costs, conditionNone)
+	return nfa.compact(costs)
+}
+
+// isMatch returns true if the given state is matching
+func (nfa *fuzzyNFA) isMatch(state nfaState) bool {
+	for _, nodeIndex := range state.nodes {
+		if nfa.nodes[nodeIndex].final {
+			return true
+		}
+	}
+
+	// The end of the word may allow to follow some new empty transitions
+	costs := nfa.newCosts()
+	for counter, nodeIndex := range state.nodes {
+		costs[nodeIndex] = state.costs[counter]
+	}
+	nfa.closure(costs, conditionEnd)
+
+	for nodeIndex, cost := range costs {
+		if cost <= nfa.distanceMax && nfa.nodes[nodeIndex].final {
+			return true
+		}
+	}
+	return false
+}
+
+// canMatch returns true if the given state can match
+func (nfa *fuzzyNFA) canMatch(state nfaState) bool {
+	return len(state.nodes) > 0
+}
+
+// newCosts allocates the costs of all the nodes, all set as not reached
+func (nfa *fuzzyNFA) newCosts() []int {
+	costs := make([]int, len(nfa.nodes))
+	for i := range costs {
+		costs[i] = noCost
+	}
+	return costs
+}
+
+// closure completes the given costs with the nodes that can be reached without consuming any rune: by
+// following the empty transitions or by deleting the rune expected by a node. As all the costs are small
+// integers, the nodes are simply processed cost by cost.
+func (nfa *fuzzyNFA) closure(costs []int, allowedCondition emptyCondition) {
+	stack := make([]int, 0, len(costs))
+
+	for level := 0; level <= nfa.distanceMax; level++ {
+		for nodeIndex, cost := range costs {
+			if cost == level {
+				stack = append(stack, nodeIndex)
+			}
+		}
+
+		for len(stack) > 0 {
+			nodeIndex := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			node := &nfa.nodes[nodeIndex]
+
+			if node.condition == conditionNone || node.condition == allowedCondition {
+				for _, target := range node.epsilons {
+					if costs[target] > level {
+						costs[target] = level
+						stack = append(stack, target)
+					}
+				}
+			}
+
+			// Deletion of the rune expected by the node
+			if node.accept != nil {
+				relax(costs, node.next, level+1)
+			}
+		}
+	}
+}
+
+// compact converts the costs of all the nodes to a state, dropping the nodes exceeding the maximum distance
+func (nfa *fuzzyNFA) compact(costs []int) nfaState {
+	nodes := make([]int, 0, 4)
+	values := make([]int, 0, 4)
+	for nodeIndex, cost := range costs {
+		if cost <= nfa.distanceMax {
+			nodes = append(nodes, nodeIndex)
+			values = append(values, cost)
+		}
+	}
+
+	return nfaState{
+		nodes: nodes,
+		costs: values,
+	}
+}
+
+// relax lowers the cost of the node if the given cost is better
+func relax(costs []int, nodeIndex int, cost int) {
+	if cost < costs[nodeIndex] {
+		costs[nodeIndex] = cost
+	}
+}
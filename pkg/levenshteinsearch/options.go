@@ -0,0 +1,266 @@
+package levenshteinsearch
+
+import (
+	"math"
+	"sort"
+)
+
+// SearchOptions holds the parameters of a search done with SearchWithOptions
+type SearchOptions struct {
+	// DistanceMax is the maximum distance between the searched term and the words found
+	DistanceMax int
+	// Metric is the distance used, the Levenshtein distance by default. The fields Layout and Equivalences
+	// are only used with the Levenshtein distance
+	Metric DistanceMetric
+	// Fuzziness is, if not nil, the policy giving the maximum distance from the searched term. It replaces
+	// DistanceMax
+	Fuzziness *Fuzziness
+	// PrefixLength is the number of runes at the beginning of the searched term that must match exactly.
+	// The search goes directly down the trie along these runes, which greatly reduces the number of nodes
+	// visited
+	PrefixLength int
+	// Layout is, if not nil, the keyboard layout lowering the cost of the substitutions of adjacent keys.
+	// The maximum distance then bounds the cost of the edits rather than their number
+	Layout *KeyboardLayout
+	// Equivalences is, if not nil, the classes of runes or sequences of runes that are replaced by each other
+	// for a specific cost, such as "e" and "é"
+	Equivalences *Equivalences
+	// CaseInsensitive requests the runes to be compared with Unicode simple case folding. The words are
+	// still returned as they were put
+	CaseInsensitive bool
+	// PreferExactCase ranks, for the same cost and distance, the words with the fewest case differences first
+	PreferExactCase bool
+	// Reranker is, if not nil, the blend of scores ordering the results instead of the cost
+	Reranker *Reranker
+	// WithEditScript requests each result to carry the edit script transforming the searched term into
+	// the word found
+	WithEditScript bool
+	// WithTranspositions requests the edit scripts to use transpositions of adjacent runes
+	WithTranspositions bool
+}
+
+// SearchResult is a single word found by SearchWithOptions
+type SearchResult struct {
+	// Word is the word found
+	Word string
+	// Information is the information of the word found
+	Information *WordInformation
+	// Distance is the number of edits between the searched term and the word. This is the Levenshtein
+	// distance unless the options make some edits free
+	Distance int
+	// Cost is the total cost of the edits between the searched term and the word. This is the Levenshtein
+	// distance unless the options value the edits differently
+	Cost float64
+	// NormalizedDistance is the distance divided by the length of the longest of the searched term and the word
+	NormalizedDistance float64
+	// CaseDifferences is the number of runes matched despite a different case. It is only filled for the
+	// case insensitive searches
+	CaseDifferences int
+	// Score is the score given by the reranker, from 0 to 1. It is only filled if a reranker is given by the
+	// options
+	Score float64
+	// EditScript is the sequence of operations transforming the searched term into the word. It is only
+	// filled if requested by the options
+	EditScript []EditOperation
+}
+
+// rankingAutomaton is an automaton able to give the cost of its matching states
+type rankingAutomaton interface {
+	Automaton
+	// getCost returns the cost and the number of edits of a matching state
+	getCost(state State) (float64, int)
+}
+
+// SearchWithOptions returns all the words of the dictionary close to the searched term, as defined by the
+// options. The results are sorted by increasing cost, then by increasing distance, then by decreasing count,
+// then alphabetically. If PreferExactCase is set, the case differences are compared right after the distance.
+// If a reranker is given, the results are finally sorted by decreasing score.
+func (dictionary *Dictionary) SearchWithOptions(searchedTerm string, options SearchOptions) []SearchResult {
+	distanceMax := options.DistanceMax
+	if options.Fuzziness != nil {
+		distanceMax = options.Fuzziness.GetDistanceMax(searchedTerm)
+	}
+
+	// Go down the trie along the exact prefix
+	searchedRunes := []rune(searchedTerm)
+	prefixLength := min(max(options.PrefixLength, 0), len(searchedRunes))
+	prefix := string(searchedRunes[:prefixLength])
+	suffix := string(searchedRunes[prefixLength:])
+
+	results := make([]SearchResult, 0)
+
+	// When ignoring the case, the prefix may lead to several nodes
+	startNodes := make(map[string]*RuneTrie)
+	if options.CaseInsensitive {
+		startNodes = dictionary.Root.getFoldedNodes(prefix)
+		suffix = FoldCase(suffix)
+	} else if startNode := dictionary.Root.getNode(prefix); startNode != nil {
+		startNodes[prefix] = startNode
+	}
+
+	// Create the Automaton for the rest of the term
+	automaton := options.createAutomaton(suffix, distanceMax)
+
+	visit := func(word string, information *WordInformation, state State) {
+		cost, distance := automaton.getCost(state)
+		if options.Fuzziness != nil && !options.Fuzziness.Accepts(searchedTerm, word, distance) {
+			return
+		}
+
+		result := SearchResult{
+			Word:               word,
+			Information:        information,
+			Distance:           distance,
+			Cost:               cost,
+			NormalizedDistance: GetNormalizedDistance(searchedTerm, word, distance),
+		}
+		if options.WithEditScript || options.CaseInsensitive {
+			script := options.computeEditScript(searchedRunes, []rune(word), prefixLength)
+			if options.CaseInsensitive {
+				result.CaseDifferences = countCaseDifferences(script)
+			}
+			if options.WithEditScript {
+				result.EditScript = script
+			}
+		}
+		results = append(results, result)
+	}
+
+	for startPrefix, startNode := range startNodes {
+		startNode.searchAll(automaton, startPrefix, nil, nil, visit)
+	}
+
+	sortSearchResults(results, options.PreferExactCase)
+	if options.Reranker != nil {
+		options.Reranker.Rerank(searchedTerm, results)
+	}
+
+	return results
+}
+
+// createAutomaton creates the automaton needed by the options: the automaton of the metric, which is the
+// regular Levenshtein automaton unless the substitutions have a specific cost
+func (options *SearchOptions) createAutomaton(searchedTerm string, distanceMax int) rankingAutomaton {
+	var automaton rankingAutomaton
+	switch {
+	case options.Metric == MetricHamming:
+		automaton = CreateHammingAutomaton(searchedTerm, distanceMax)
+	case options.Metric == MetricLCS:
+		automaton = CreateLCSAutomaton(searchedTerm, distanceMax)
+	case options.Layout == nil && options.Equivalences == nil:
+		automaton = CreateAutomaton(searchedTerm, distanceMax)
+	default:
+		automaton = createWeightedAutomaton(searchedTerm, float64(distanceMax), options.getSubstitutionCost(), options.getSequenceRules())
+	}
+
+	if options.CaseInsensitive {
+		return CreateCaseFoldingAutomaton(automaton)
+	}
+	return automaton
+}
+
+// getSubstitutionCost returns the cost of the substitutions defined by the options. If several options
+// apply, the lowest cost is kept
+func (options *SearchOptions) getSubstitutionCost() SubstitutionCost {
+	costs := make([]SubstitutionCost, 0, 2)
+	if options.Layout != nil {
+		costs = append(costs, options.Layout.GetSubstitutionCost)
+	}
+	if options.Equivalences != nil {
+		costs = append(costs, options.Equivalences.GetSubstitutionCost)
+	}
+
+	switch len(costs) {
+	case 0:
+		return unitSubstitutionCost
+	case 1:
+		return costs[0]
+	default:
+		return func(termRune rune, wordRune rune) float64 {
+			best := 1.0
+			for _, cost := range costs {
+				best = math.Min(best, cost(termRune, wordRune))
+			}
+			return best
+		}
+	}
+}
+
+// getSequenceRules returns the replacements of sequences defined by the options
+func (options *SearchOptions) getSequenceRules() []sequenceRule {
+	if options.Equivalences == nil {
+		return nil
+	}
+	return options.Equivalences.getSequenceRules()
+}
+
+// computeEditScript computes the edit script of a word found with the options. The script is computed on
+// the folded runes when ignoring the case, but still gives the original runes
+func (options *SearchOptions) computeEditScript(searchedRunes []rune, wordRunes []rune, prefixLength int) []EditOperation {
+	queryRunes := searchedRunes
+	foundRunes := wordRunes
+	if options.CaseInsensitive {
+		queryRunes = foldRunes(searchedRunes)
+		foundRunes = foldRunes(wordRunes)
+	}
+
+	var operations []EditOperation
+	switch options.Metric {
+	case MetricHamming:
+		operations = computeHammingEditScript(queryRunes, foundRunes, unitSubstitutionCost)
+	case MetricLCS:
+		operations = computePrefixedEditScript(queryRunes, foundRunes, prefixLength, forbiddenSubstitutionCost, nil, false)
+	default:
+		operations = computePrefixedEditScript(queryRunes, foundRunes, prefixLength, options.getSubstitutionCost(), options.getSequenceRules(), options.WithTranspositions)
+	}
+
+	if options.CaseInsensitive {
+		for i := range operations {
+			if operations[i].QueryLength > 0 {
+				operations[i].QueryRune = searchedRunes[operations[i].QueryPosition]
+			}
+			if operations[i].WordLength > 0 {
+				operations[i].WordRune = wordRunes[operations[i].WordPosition]
+			}
+		}
+	}
+	return operations
+}
+
+// sortSearchResults sorts the results by increasing cost, then by increasing distance, then by decreasing
+// count, then alphabetically. If preferExactCase is true, the results with fewer case differences come
+// first for the same cost and distance
+func sortSearchResults(results []SearchResult, preferExactCase bool) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Cost != results[j].Cost {
+			return results[i].Cost < results[j].Cost
+		}
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		if preferExactCase && results[i].CaseDifferences != results[j].CaseDifferences {
+			return results[i].CaseDifferences < results[j].CaseDifferences
+		}
+		if results[i].Information.Count != results[j].Information.Count {
+			return results[i].Information.Count > results[j].Information.Count
+		}
+		return results[i].Word < results[j].Word
+	})
+}
+
+// computePrefixedEditScript computes the edit script of a word sharing its first prefixLength runes with the
+// searched term. The prefix is kept as is, so that the script is coherent with the distance of the search
+func computePrefixedEditScript(searchedRunes []rune, wordRunes []rune, prefixLength int, substitutionCost SubstitutionCost, sequences []sequenceRule, withTranspositions bool) []EditOperation {
+	operations := make([]EditOperation, 0, len(wordRunes))
+	for i := 0; i < prefixLength; i++ {
+		operations = append(operations, EditOperation{Type: EditMatch, QueryPosition: i, WordPosition: i, QueryRune: searchedRunes[i], WordRune: wordRunes[i], QueryLength: 1, WordLength: 1})
+	}
+
+	for _, operation := range computeEditScript(searchedRunes[prefixLength:], wordRunes[prefixLength:], substitutionCost, sequences, withTranspositions) {
+		operation.QueryPosition += prefixLength
+		operation.WordPosition += prefixLength
+		operations = append(operations, operation)
+	}
+
+	return operations
+}
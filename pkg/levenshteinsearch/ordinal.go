@@ -0,0 +1,129 @@
+package levenshteinsearch
+
+import (
+	"sort"
+)
+
+// Rank returns the lexicographic rank of the word among the words of the dictionary, starting at 0, and true
+// if the word is in the dictionary. If it is not, the rank returned is the one the word would have. The
+// words are ordered by their runes, which is also the order of their UTF-8 bytes. The rank is found in
+// O(length × log(alphabet)), by a binary search among the sorted children of each node along the word.
+//
+// As long as the dictionary is not modified, the rank is a minimal perfect hash of the words: each word has
+// a different rank between 0 and UniqueWordCount-1. After a modification, the first call of Rank, Select,
+// CountRange, Range or Prefix recomputes the counts of the whole trie in O(nodes), so that Put and Remove
+// do not pay for them. Like the modifications, this first call must not run concurrently with other calls.
+func (dictionary *Dictionary) Rank(word string) (int, bool) {
+	rank := dictionary.countLess(word)
+	information := dictionary.Get(word)
+	return rank, information != nil
+}
+
+// Select returns the word having the given lexicographic rank, and false if the rank is out of the range
+// of the dictionary. As for Rank, the cost is O(length × log(alphabet)).
+func (dictionary *Dictionary) Select(rank int) (string, bool) {
+	dictionary.updateCounts()
+	node := &dictionary.Root
+	if rank < 0 || rank >= node.wordCount {
+		return "", false
+	}
+
+	runes := make([]rune, 0)
+	for {
+		if node.information != nil {
+			if rank == 0 {
+				return string(runes), true
+			}
+			rank--
+		}
+
+		// The child holding the rank is the last one preceded by at most rank words
+		i := sort.Search(len(node.characters), func(i int) bool {
+			return node.wordsBefore[i+1] > rank
+		})
+		rank -= node.wordsBefore[i]
+		runes = append(runes, node.characters[i])
+		node = node.children[node.characters[i]]
+	}
+}
+
+// CountRange returns the number of words of the dictionary greater or equal to low and lower than high.
+// As for Rank, the cost is O(length × log(alphabet)).
+func (dictionary *Dictionary) CountRange(low string, high string) int {
+	if high <= low {
+		return 0
+	}
+	return dictionary.countLess(high) - dictionary.countLess(low)
+}
+
+// countLess returns the number of words of the dictionary lexicographically lower than the key. Only the
+// nodes along the key are visited, the words of the branches before the key being given by their count.
+func (dictionary *Dictionary) countLess(key string) int {
+	dictionary.updateCounts()
+	count := 0
+	node := &dictionary.Root
+	for _, r := range key {
+		// The word of the node is a prefix of the key
+		if node.information != nil {
+			count++
+		}
+		if len(node.characters) == 0 {
+			break
+		}
+		count += node.wordsBefore[node.searchCharacter(r)]
+
+		node = node.children[r]
+		if node == nil {
+			break
+		}
+	}
+	return count
+}
+
+// searchCharacter returns the position of the character among the sorted characters of the children, or the
+// position where it would be inserted
+func (trie *RuneTrie) searchCharacter(character rune) int {
+	return sort.Search(len(trie.characters), func(i int) bool {
+		return trie.characters[i] >= character
+	})
+}
+
+// updateCounts computes the counts of the nodes and the order of their children, if words were added or
+// removed since they were last computed. The full trie is then visited once: the modifications stay cheap,
+// the cost being paid by the first ordered query following them.
+func (dictionary *Dictionary) updateCounts() {
+	if !dictionary.countsOutdated {
+		return
+	}
+	dictionary.Root.updateCounts()
+	dictionary.countsOutdated = false
+}
+
+// updateCounts is the recursive part of updateCounts, computing the counts of the children first
+func (trie *RuneTrie) updateCounts() {
+	for _, child := range trie.children {
+		child.updateCounts()
+	}
+	trie.sortCharacters()
+	trie.wordCount = 0
+	if trie.information != nil {
+		trie.wordCount = 1
+	}
+	trie.wordCount += trie.wordsBefore[len(trie.characters)]
+}
+
+// sortCharacters rebuilds the sorted characters of the children and their counts from the children
+func (trie *RuneTrie) sortCharacters() {
+	trie.characters = trie.characters[:0]
+	for character := range trie.children {
+		trie.characters = append(trie.characters, character)
+	}
+	sort.Slice(trie.characters, func(i, j int) bool {
+		return trie.characters[i] < trie.characters[j]
+	})
+
+	trie.wordsBefore = append(trie.wordsBefore[:0], 0)
+	for i, character := range trie.characters {
+		trie.wordsBefore = append(trie.wordsBefore, trie.wordsBefore[i]+trie.children[character].wordCount)
+	}
+}
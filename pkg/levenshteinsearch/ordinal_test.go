@@ -0,0 +1,122 @@
+package levenshteinsearch
+
+import (
+	"sort"
+	"testing"
+)
+
+// checkRanks compares the ranks and the selections of the dictionary with the sorted list of its words
+func checkRanks(t *testing.T, dict *Dictionary) {
+	words := make([]string, 0, dict.UniqueWordCount)
+	dict.Root.forEachWord("", func(word string, information *WordInformation) {
+		words = append(words, word)
+	})
+	sort.Strings(words)
+
+	for i, word := range words {
+		if rank, found := dict.Rank(word); rank != i || !found {
+			t.Errorf("Expected rank %v for %v, found %v (%v)", i, word, rank, found)
+		}
+		if selected, found := dict.Select(i); selected != word || !found {
+			t.Errorf("Expected %v at rank %v, found %v (%v)", word, i, selected, found)
+		}
+	}
+	if dict.Root.wordCount != len(words) {
+		t.Errorf("Expected %v words at the root, found %v", len(words), dict.Root.wordCount)
+	}
+	if _, found := dict.Select(len(words)); found {
+		t.Errorf("Expected no word at rank %v", len(words))
+	}
+	if _, found := dict.Select(-1); found {
+		t.Error("Expected no word at rank -1")
+	}
+}
+
+func TestRankAndSelect(t *testing.T) {
+
+	dict := CreateDictionary()
+	for _, word := range []string{"rabbit", "rabbits", "rab", "rat", "été", "", "zoo", "rabbit"} {
+		dict.Put(word)
+	}
+	checkRanks(t, dict)
+
+	// "" < rab < rabbit < rabbits < rat < zoo < été
+	if rank, found := dict.Rank("rabbit"); rank != 2 || !found {
+		t.Errorf("Expected rank 2 for rabbit, found %v (%v)", rank, found)
+	}
+	if rank, found := dict.Rank("rabb"); rank != 2 || found {
+		t.Errorf("Expected rank 2 for the missing rabb, found %v (%v)", rank, found)
+	}
+	if rank, found := dict.Rank("zzz"); rank != 6 || found {
+		t.Errorf("Expected rank 6 for the missing zzz, found %v (%v)", rank, found)
+	}
+	if word, _ := dict.Select(6); word != "été" {
+		t.Errorf("Expected été at rank 6, found %v", word)
+	}
+
+	if count := dict.CountRange("rab", "rat"); count != 3 {
+		t.Errorf("Expected 3 words from rab to rat, found %v", count)
+	}
+	if count := dict.CountRange("rabb", "s"); count != 3 {
+		t.Errorf("Expected 3 words from rabb to s, found %v", count)
+	}
+	if count := dict.CountRange("s", "a"); count != 0 {
+		t.Errorf("Expected no word in an empty range, found %v", count)
+	}
+}
+
+func TestRankMatchesBruteForce(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	checkRanks(t, dict)
+
+	dict.Prune(2)
+	checkRanks(t, dict)
+
+	for _, word := range []string{"the", "rabbit", "a", "alice", "unknown"} {
+		dict.Remove(word)
+	}
+	checkRanks(t, dict)
+
+	other := CreateDictionary()
+	for _, word := range []string{"the", "rabbit", "queen", "unknown"} {
+		other.Put(word)
+	}
+	dict.Subtract(other)
+	checkRanks(t, dict)
+
+	dict.Merge(other)
+	checkRanks(t, dict)
+
+	dict.KeepTop(100)
+	checkRanks(t, dict)
+
+	dict.Intersect(other)
+	checkRanks(t, dict)
+
+	for _, word := range []string{"alice", "queen", "zebra"} {
+		dict.Put(word)
+	}
+	checkRanks(t, dict)
+}
+
+func TestRankWithAutoPrune(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	dict.EnableAutoPrune(200, PruneSpaceSaving)
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	checkRanks(t, dict)
+}
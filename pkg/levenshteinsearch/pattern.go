@@ -0,0 +1,211 @@
+package levenshteinsearch
+
+import (
+	"fmt"
+)
+
+// PatternAutomaton is an automaton matching the words following a wildcard pattern, with a maximum number of
+// edits. The pattern accepts the following syntax:
+//
+//	?       matches any single rune
+//	*       matches any sequence of runes, including the empty one
+//	[abc]   matches one of the runes of the class. Ranges such as [a-z] are accepted
+//	[!abc]  matches any rune that is not part of the class. [^abc] is also accepted
+//	\c      matches the rune c, even if it is a special character
+type PatternAutomaton struct {
+	pattern string
+	nfa     *fuzzyNFA
+}
+
+// CreatePatternAutomaton creates a new automaton for the given pattern. An error is returned if the pattern
+// is malformed
+func CreatePatternAutomaton(pattern string, distanceMax int) (*PatternAutomaton, error) {
+	nodes, err := compilePattern([]rune(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PatternAutomaton{
+		pattern: pattern,
+		nfa: &fuzzyNFA{
+			nodes:       nodes,
+			startNode:   0,
+			distanceMax: distanceMax,
+		},
+	}, nil
+}
+
+// GetDistanceMax returns the maximum distance defined for this automaton
+func (automaton *PatternAutomaton) GetDistanceMax() int {
+	return automaton.nfa.distanceMax
+}
+
+// GetPattern returns the pattern defined for this automaton
+func (automaton *PatternAutomaton) GetPattern() string {
+	return automaton.pattern
+}
+
+// Start gives the initial state allowing to step into the automaton
+func (automaton *PatternAutomaton) Start() State {
+	return automaton.nfa.start()
+}
+
+// Step steps through the automaton by generating the next state based on the current one + the given
+// char.
+func (automaton *PatternAutomaton) Step(state State, character rune) State {
+	return automaton.nfa.step(state.(nfaState), character)
+}
+
+// IsMatch returns true if the given states is matching
+func (automaton *PatternAutomaton) IsMatch(state State) bool {
+	return automaton.nfa.isMatch(state.(nfaState))
+}
+
+// CanMatch returns true if the given states can match
+func (automaton *PatternAutomaton) CanMatch(state State) bool {
+	return automaton.nfa.canMatch(state.(nfaState))
+}
+
+// SearchPattern returns all the words of the dictionary matching the pattern with at most distanceMax
+// edits. See PatternAutomaton for the syntax of the pattern.
+func (dictionary *Dictionary) SearchPattern(pattern string, distanceMax int) (map[string]*WordInformation, error) {
+	automaton, err := CreatePatternAutomaton(pattern, distanceMax)
+	if err != nil {
+		return nil, err
+	}
+
+	return dictionary.Search(automaton), nil
+}
+
+// compilePattern converts the pattern to the nodes of a non deterministic automaton. The nodes are created
+// in sequence, the last one being the final node.
+func compilePattern(pattern []rune) ([]nfaNode, error) {
+	nodes := make([]nfaNode, 0, len(pattern)+1)
+
+	for position := 0; position < len(pattern); position++ {
+		switch pattern[position] {
+		case '?':
+			nodes = append(nodes, nfaNode{
+				accept: acceptAny,
+				next:   len(nodes) + 1,
+			})
+		case '*':
+			// An empty node looping on a node consuming any rune
+			loop := len(nodes)
+			nodes = append(nodes,
+				nfaNode{
+					epsilons: []int{loop + 1, loop + 2},
+				},
+				nfaNode{
+					accept: acceptAny,
+					next:   loop,
+				})
+		case '[':
+			accept, end, err := compileClass(pattern, position)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, nfaNode{
+				accept: accept,
+				next:   len(nodes) + 1,
+			})
+			position = end
+		case '\\':
+			if position+1 == len(pattern) {
+				return nil, fmt.Errorf("pattern ends with a trailing escape character")
+			}
+			position++
+			nodes = append(nodes, nfaNode{
+				accept: acceptRune(pattern[position]),
+				next:   len(nodes) + 1,
+			})
+		default:
+			nodes = append(nodes, nfaNode{
+				accept: acceptRune(pattern[position]),
+				next:   len(nodes) + 1,
+			})
+		}
+	}
+
+	nodes = append(nodes, nfaNode{
+		final: true,
+	})
+
+	return nodes, nil
+}
+
+// runeRange is an inclusive range of runes
+type runeRange struct {
+	low  rune
+	high rune
+}
+
+// compileClass compiles the character class starting at the given position of the pattern. It returns the
+// function accepting the runes of the class and the position of the closing bracket.
+func compileClass(pattern []rune, start int) (func(character rune) bool, int, error) {
+	position := start + 1
+
+	negated := false
+	if position < len(pattern) && (pattern[position] == '!' || pattern[position] == '^') {
+		negated = true
+		position++
+	}
+
+	ranges := make([]runeRange, 0, 4)
+
+	// A closing bracket just after the opening one is a regular rune
+	first := true
+	for ; position < len(pattern); position++ {
+		character := pattern[position]
+		if character == ']' && !first {
+			return acceptRanges(ranges, negated), position, nil
+		}
+		first = false
+
+		if character == '\\' {
+			if position+1 == len(pattern) {
+				break
+			}
+			position++
+			character = pattern[position]
+		}
+
+		high := character
+		if position+2 < len(pattern) && pattern[position+1] == '-' && pattern[position+2] != ']' {
+			high = pattern[position+2]
+			if high < character {
+				return nil, 0, fmt.Errorf("invalid range %c-%c in character class at position %v", character, high, start)
+			}
+			position += 2
+		}
+
+		ranges = append(ranges, runeRange{low: character, high: high})
+	}
+
+	return nil, 0, fmt.Errorf("character class at position %v is not closed", start)
+}
+
+// acceptAny accepts any rune
+func acceptAny(character rune) bool {
+	return true
+}
+
+// acceptRune returns a function accepting only the given rune
+func acceptRune(expected rune) func(character rune) bool {
+	return func(character rune) bool {
+		return character == expected
+	}
+}
+
+// acceptRanges returns a function accepting the runes of the given ranges, or the runes out of the ranges
+// if negated
+func acceptRanges(ranges []runeRange, negated bool) func(character rune) bool {
+	return func(character rune) bool {
+		for _, r := range ranges {
+			if r.low <= character && character <= r.high {
+				return !negated
+			}
+		}
+		return negated
+	}
+}
@@ -0,0 +1,132 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestSearchPattern(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("color")
+	dict.Put("colour")
+	dict.Put("colours")
+	dict.Put("colander")
+	dict.Put("gray")
+	dict.Put("grey")
+	dict.Put("groy")
+
+	result, err := dict.SearchPattern("colo?r*", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 || result["colour"] == nil || result["colours"] == nil {
+		t.Errorf("Expected to find 'colour' and 'colours' with 'colo?r*', found %v", result)
+	}
+
+	result, err = dict.SearchPattern("gr[ae]y", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 || result["gray"] == nil || result["grey"] == nil {
+		t.Errorf("Expected to find 'gray' and 'grey' with 'gr[ae]y', found %v", result)
+	}
+
+	result, err = dict.SearchPattern("gr[!ae]y", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result["groy"] == nil {
+		t.Errorf("Expected to find 'groy' with 'gr[!ae]y', found %v", result)
+	}
+
+	result, err = dict.SearchPattern("gr[a-f]y", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 3 {
+		t.Errorf("Expected to find 'gray', 'grey' and 'groy' with 'gr[a-f]y' and a distance of 1, found %v", result)
+	}
+
+	result, err = dict.SearchPattern("colo?r*", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 3 || result["color"] == nil {
+		t.Errorf("Expected to find 'color', 'colour' and 'colours' with 'colo?r*' and a distance of 1, found %v", result)
+	}
+
+	result, err = dict.SearchPattern("*", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != dict.UniqueWordCount {
+		t.Errorf("Expected to find all the words with '*', found %v", result)
+	}
+}
+
+func TestPatternAutomatonMatchesBruteForce(t *testing.T) {
+
+	words := []string{"", "a", "ab", "abc", "cab", "banana", "bandana", "cabana"}
+	patterns := []string{"a*", "*a", "b?n*a", "a?c*", "*an*", "?"}
+
+	for _, pattern := range patterns {
+		for distance := 0; distance < 3; distance++ {
+			automaton, err := CreatePatternAutomaton(pattern, distance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, word := range words {
+				state := automaton.Start()
+				for _, c := range word {
+					state = automaton.Step(state, c)
+				}
+				expected := patternDistance([]rune(pattern), []rune(word)) <= distance
+				if automaton.IsMatch(state) != expected {
+					t.Errorf("Unexpected match result for pattern '%v', word '%v' and distance %v", pattern, word, distance)
+				}
+			}
+		}
+	}
+}
+
+func TestPatternErrors(t *testing.T) {
+	for _, pattern := range []string{"abc\\", "gr[ae", "[z-a]"} {
+		if _, err := CreatePatternAutomaton(pattern, 0); err == nil {
+			t.Errorf("Expected pattern '%v' to be rejected", pattern)
+		}
+	}
+}
+
+// patternDistance is a reference implementation computing the edit distance between a word and the closest
+// word matching a pattern made only of literals, '?' and '*'.
+func patternDistance(pattern []rune, word []rune) int {
+	// distances[i][j] is the distance between pattern[:i] and word[:j]
+	distances := make([][]int, len(pattern)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(word)+1)
+	}
+	for j := 1; j <= len(word); j++ {
+		distances[0][j] = j
+	}
+	for i := 1; i <= len(pattern); i++ {
+		for j := 0; j <= len(word); j++ {
+			if pattern[i-1] == '*' {
+				best := distances[i-1][j]
+				if j > 0 && distances[i][j-1] < best {
+					best = distances[i][j-1]
+				}
+				distances[i][j] = best
+				continue
+			}
+			best := distances[i-1][j] + 1
+			if j > 0 {
+				cost := 1
+				if pattern[i-1] == '?' || pattern[i-1] == word[j-1] {
+					cost = 0
+				}
+				best = minimum(best, distances[i][j-1]+1, distances[i-1][j-1]+cost)
+			}
+			distances[i][j] = best
+		}
+	}
+	return distances[len(pattern)][len(word)]
+}
@@ -0,0 +1,156 @@
+package levenshteinsearch
+
+import (
+	"strings"
+)
+
+// PhoneticEncoder converts a word to the codes representing its pronunciation. Words sounding alike should
+// share at least one code.
+type PhoneticEncoder interface {
+	// Encode returns the phonetic codes of the word. An empty list is returned if the word has no code.
+	Encode(word string) []string
+}
+
+// SoundexEncoder encodes the words with the American Soundex algorithm
+type SoundexEncoder struct{}
+
+// CreateSoundexEncoder creates a new Soundex encoder
+func CreateSoundexEncoder() *SoundexEncoder {
+	return &SoundexEncoder{}
+}
+
+// soundexCodes gives the digit of each consonant. The vowels are coded as 0 and separate the consonants, while
+// H and W are ignored
+var soundexCodes = map[rune]byte{
+	'A': '0', 'E': '0', 'I': '0', 'O': '0', 'U': '0', 'Y': '0',
+	'B': '1', 'F': '1', 'P': '1', 'V': '1',
+	'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+	'D': '3', 'T': '3',
+	'L': '4',
+	'M': '5', 'N': '5',
+	'R': '6',
+}
+
+// Encode returns the Soundex code of the word, made of its first letter followed by three digits. An
+// empty list is returned if the word has no letter.
+func (encoder *SoundexEncoder) Encode(word string) []string {
+	code := make([]byte, 0, 4)
+	var previous byte
+
+	for _, character := range strings.ToUpper(word) {
+		if character == 'H' || character == 'W' {
+			if len(code) == 0 {
+				code = append(code, byte(character))
+			}
+			continue
+		}
+		digit, found := soundexCodes[character]
+		if !found {
+			continue
+		}
+		if len(code) == 0 {
+			code = append(code, byte(character))
+		} else if digit != '0' && digit != previous {
+			code = append(code, digit)
+			if len(code) == 4 {
+				break
+			}
+		}
+		previous = digit
+	}
+
+	if len(code) == 0 {
+		return []string{}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return []string{string(code)}
+}
+
+// phoneticIndex files the words of a dictionary under their phonetic codes. The codes are themselves
+// stored in a dictionary, so that they can be searched with a Levenshtein distance.
+type phoneticIndex struct {
+	encoder     PhoneticEncoder
+	codes       *Dictionary
+	wordsByCode map[string][]string
+}
+
+// addWord files a new word under its codes
+func (index *phoneticIndex) addWord(word string) {
+	for _, code := range index.encoder.Encode(word) {
+		if index.codes.Put(code) {
+			index.wordsByCode[code] = make([]string, 0, 1)
+		}
+		index.wordsByCode[code] = append(index.wordsByCode[code], word)
+	}
+}
+
+// removeWord removes a word from its codes. The codes left without word are kept in the dictionary of the
+// codes but no longer give any word
+func (index *phoneticIndex) removeWord(word string) {
+	for _, code := range index.encoder.Encode(word) {
+		words := index.wordsByCode[code]
+		for i, candidate := range words {
+			if candidate == word {
+				index.wordsByCode[code] = append(words[:i], words[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// CreatePhoneticDictionary creates a new dictionary that files all the words put under their phonetic codes
+func CreatePhoneticDictionary(encoder PhoneticEncoder) *Dictionary {
+	dictionary := CreateDictionary()
+	dictionary.EnablePhoneticIndex(encoder)
+	return dictionary
+}
+
+// EnablePhoneticIndex files all the words of the dictionary under their phonetic codes. The words put
+// afterwards are also filed. Enabling the index again replaces the previous one.
+func (dictionary *Dictionary) EnablePhoneticIndex(encoder PhoneticEncoder) {
+	index := &phoneticIndex{
+		encoder:     encoder,
+		codes:       CreateDictionary(),
+		wordsByCode: make(map[string][]string),
+	}
+
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		index.addWord(word)
+	})
+
+	dictionary.phonetic = index
+}
+
+// SearchPhonetic returns all the words of the dictionary sounding like the searched term, that is having a
+// phonetic code at a Levenshtein distance lower or equal to codeDistanceMax of a code of the term. The
+// phonetic index must have been enabled, otherwise no word is returned.
+func (dictionary *Dictionary) SearchPhonetic(searchedTerm string, codeDistanceMax int) map[string]*WordInformation {
+	results := map[string]*WordInformation{}
+
+	index := dictionary.phonetic
+	if index == nil {
+		return results
+	}
+
+	for _, code := range index.encoder.Encode(searchedTerm) {
+		for foundCode := range index.codes.SearchAll(code, codeDistanceMax) {
+			for _, word := range index.wordsByCode[foundCode] {
+				results[word] = dictionary.Get(word)
+			}
+		}
+	}
+
+	return results
+}
+
+// SearchAllWithPhonetic returns the words returned by SearchAll merged with the ones returned by
+// SearchPhonetic
+func (dictionary *Dictionary) SearchAllWithPhonetic(searchedTerm string, distanceMax int, codeDistanceMax int) map[string]*WordInformation {
+	results := dictionary.SearchAll(searchedTerm, distanceMax)
+	for word, information := range dictionary.SearchPhonetic(searchedTerm, codeDistanceMax) {
+		results[word] = information
+	}
+	return results
+}
@@ -0,0 +1,71 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestSoundex(t *testing.T) {
+
+	encoder := CreateSoundexEncoder()
+
+	expected := map[string]string{
+		"Robert":   "R163",
+		"Rupert":   "R163",
+		"Rubin":    "R150",
+		"Ashcraft": "A261",
+		"Tymczak":  "T522",
+		"Pfister":  "P236",
+		"Honeyman": "H555",
+	}
+
+	for word, code := range expected {
+		codes := encoder.Encode(word)
+		if len(codes) != 1 || codes[0] != code {
+			t.Errorf("Expected code %v for '%v', found %v", code, word, codes)
+		}
+	}
+
+	if len(encoder.Encode("123")) != 0 {
+		t.Error("Expected no code for a word without letters")
+	}
+}
+
+func TestSearchPhonetic(t *testing.T) {
+
+	dict := CreatePhoneticDictionary(CreateDoubleMetaphoneEncoder())
+
+	dict.Put("Smith")
+	dict.Put("Schmidt")
+	dict.Put("Smyth")
+	dict.Put("Jones")
+
+	result := dict.SearchPhonetic("Smythe", 0)
+	if len(result) != 3 || result["Jones"] != nil {
+		t.Errorf("Expected to find 'Smith', 'Schmidt' and 'Smyth' for 'Smythe', found %v", result)
+	}
+
+	result = dict.SearchAll("Smythe", 1)
+	if len(result) != 1 {
+		t.Errorf("Expected the Levenshtein search to only find 'Smyth', found %v", result)
+	}
+
+	result = dict.SearchAllWithPhonetic("Jonas", 1, 0)
+	if len(result) != 1 || result["Jones"] == nil {
+		t.Errorf("Expected to find 'Jones' for 'Jonas', found %v", result)
+	}
+
+	// Enabling the index on an existing dictionary
+	dict = CreateDictionary()
+	dict.Put("Robert")
+	if len(dict.SearchPhonetic("Rupert", 0)) != 0 {
+		t.Error("Expected to find nothing without phonetic index")
+	}
+	dict.EnablePhoneticIndex(CreateSoundexEncoder())
+	dict.Put("Rubin")
+	result = dict.SearchPhonetic("Rupert", 0)
+	if len(result) != 1 || result["Robert"] == nil {
+		t.Errorf("Expected to find 'Robert' for 'Rupert', found %v", result)
+	}
+	result = dict.SearchPhonetic("Rupert", 2)
+	if len(result) != 2 {
+		t.Errorf("Expected to find 'Robert' and 'Rubin' for 'Rupert' with a code distance of 2, found %v", result)
+	}
+}
@@ -0,0 +1,315 @@
+package levenshteinsearch
+
+import (
+	"container/heap"
+	"hash/fnv"
+	"sort"
+)
+
+// PruningStrategy is the strategy used to keep the number of unique words of a dictionary under a limit
+// while words are put
+type PruningStrategy int
+
+const (
+	// PruneSpaceSaving keeps exactly the limit of unique words. Once the limit is reached, a new word
+	// replaces the least frequent word and inherits its count, as done by the Space-Saving algorithm. The
+	// counts are then overestimated by at most the count inherited
+	PruneSpaceSaving PruningStrategy = iota
+	// PruneCountMin counts all the words put in a Count-Min sketch. Once the limit is passed, the least
+	// frequent words are pruned to keep half of the limit. A word pruned and put again gets back the count
+	// estimated by the sketch
+	PruneCountMin
+)
+
+// Default dimensions of the Count-Min sketch
+const (
+	countMinDepth        = 4
+	countMinWidthPerWord = 4
+	countMinWidthMin     = 1024
+)
+
+// autoPruner prunes the dictionary while the words are put
+type autoPruner struct {
+	limit    int
+	strategy PruningStrategy
+	// The words by increasing count, for the Space-Saving strategy
+	words *wordHeap
+	// The counts of all the words put, for the Count-Min strategy
+	sketch *countMinSketch
+}
+
+// Prune removes all the words having a count lower than minCount, along with the branches of the trie left
+// without word. It returns the number of words removed.
+func (dictionary *Dictionary) Prune(minCount int) int {
+	return dictionary.removeWhere(func(word string, information *WordInformation) bool {
+		return information.Count < minCount
+	})
+}
+
+// KeepTop only keeps the n most frequent words, the words having the same count being kept alphabetically.
+// The branches of the trie left without word are removed. It returns the number of words removed.
+func (dictionary *Dictionary) KeepTop(n int) int {
+	if dictionary.UniqueWordCount <= n {
+		return 0
+	}
+
+	results := make([]SearchResult, 0, dictionary.UniqueWordCount)
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		results = append(results, SearchResult{Word: word, Information: information})
+	})
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Information.Count != results[j].Information.Count {
+			return results[i].Information.Count > results[j].Information.Count
+		}
+		return results[i].Word < results[j].Word
+	})
+
+	kept := make(map[*WordInformation]bool, n)
+	for _, result := range results[:max(n, 0)] {
+		kept[result.Information] = true
+	}
+
+	return dictionary.removeWhere(func(word string, information *WordInformation) bool {
+		return !kept[information]
+	})
+}
+
+// EnableAutoPrune limits the number of unique words of the dictionary while words are put, with the given
+// strategy. If the dictionary already holds more words than the limit, the least frequent ones are removed.
+func (dictionary *Dictionary) EnableAutoPrune(limit int, strategy PruningStrategy) {
+	dictionary.autoPrune = nil
+	dictionary.KeepTop(limit)
+
+	pruner := &autoPruner{
+		limit:    limit,
+		strategy: strategy,
+	}
+	if strategy == PruneCountMin {
+		pruner.sketch = createCountMinSketch(countMinDepth, max(limit*countMinWidthPerWord, countMinWidthMin))
+		dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+			pruner.sketch.add(word, information.Count)
+		})
+	}
+	dictionary.autoPrune = pruner
+	pruner.refresh(dictionary)
+}
+
+// DisableAutoPrune stops limiting the number of unique words of the dictionary
+func (dictionary *Dictionary) DisableAutoPrune() {
+	dictionary.autoPrune = nil
+}
+
+// refreshAutoPrune updates the pruner, if any, after words were added or removed without it. The least
+// frequent words are removed if the limit is passed
+func (dictionary *Dictionary) refreshAutoPrune() {
+	if dictionary.autoPrune == nil {
+		return
+	}
+	if dictionary.UniqueWordCount > dictionary.autoPrune.limit {
+		dictionary.KeepTop(dictionary.autoPrune.limit)
+		return
+	}
+	dictionary.autoPrune.refresh(dictionary)
+}
+
+// removeWhere removes the words for which the predicate is true, along with the branches left without word.
+// It returns the number of words removed.
+func (dictionary *Dictionary) removeWhere(predicate func(word string, information *WordInformation) bool) int {
+	removed := dictionary.removeNodeWhere(&dictionary.Root, "", predicate)
+	if dictionary.autoPrune != nil {
+		dictionary.autoPrune.refresh(dictionary)
+	}
+	return removed
+}
+
+// removeNodeWhere is the recursive part of removeWhere
+func (dictionary *Dictionary) removeNodeWhere(node *RuneTrie, prefix string, predicate func(word string, information *WordInformation) bool) int {
+	removed := 0
+	if node.information != nil && predicate(prefix, node.information) {
+		dictionary.removeInformation(node, prefix)
+		removed++
+	}
+
+	for character, child := range node.children {
+		removed += dictionary.removeNodeWhere(child, prefix+string(character), predicate)
+		if child.isEmpty() {
+			delete(node.children, character)
+		}
+	}
+	return removed
+}
+
+// removeWord removes a single word from the dictionary, along with the branch left without word. It returns
+// true if the word was found.
+func (dictionary *Dictionary) removeWord(word string) bool {
+	// The branch to cut starts after the last node along the word holding another word
+	node := &dictionary.Root
+	cutNode, cutCharacter := node, rune(0)
+	for i, r := range word {
+		if i == 0 || node.information != nil || len(node.children) > 1 {
+			cutNode, cutCharacter = node, r
+		}
+		node = node.children[r]
+		if node == nil {
+			return false
+		}
+	}
+	if node.information == nil {
+		return false
+	}
+
+	dictionary.removeInformation(node, word)
+	if len(node.children) == 0 && node != cutNode {
+		delete(cutNode.children, cutCharacter)
+	}
+	return true
+}
+
+// put puts the word in the dictionary, pruning it if needed
+func (pruner *autoPruner) put(dictionary *Dictionary, word string, count int) bool {
+	node := dictionary.Root.getNode(word)
+	exists := node != nil && node.information != nil
+
+	switch pruner.strategy {
+	case PruneCountMin:
+		estimate := pruner.sketch.add(word, count)
+		if exists {
+			return dictionary.putWithCount(word, count)
+		}
+		isNew := dictionary.putWithCount(word, estimate)
+		if dictionary.UniqueWordCount > pruner.limit {
+			dictionary.KeepTop(pruner.limit / 2)
+		}
+		return isNew
+
+	default:
+		if pruner.limit <= 0 {
+			return false
+		}
+		if exists {
+			dictionary.putWithCount(word, count)
+			pruner.words.update(node.information)
+			return false
+		}
+		if dictionary.UniqueWordCount >= pruner.limit && pruner.words.Len() > 0 {
+			evicted := heap.Pop(pruner.words).(*wordHeapItem)
+			count += evicted.information.Count
+			dictionary.removeWord(evicted.word)
+		}
+		isNew := dictionary.putWithCount(word, count)
+		heap.Push(pruner.words, &wordHeapItem{word: word, information: dictionary.Get(word)})
+		return isNew
+	}
+}
+
+// remove forgets a word removed from the dictionary
+func (pruner *autoPruner) remove(information *WordInformation) {
+	if pruner.strategy == PruneSpaceSaving {
+		pruner.words.remove(information)
+	}
+}
+
+// refresh rebuilds the state of the pruner after words were removed or added without it
+func (pruner *autoPruner) refresh(dictionary *Dictionary) {
+	if pruner.strategy != PruneSpaceSaving {
+		return
+	}
+
+	pruner.words = &wordHeap{items: make([]*wordHeapItem, 0, dictionary.UniqueWordCount), indexes: make(map[*WordInformation]int)}
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		pruner.words.items = append(pruner.words.items, &wordHeapItem{word: word, information: information})
+	})
+	for i, item := range pruner.words.items {
+		pruner.words.indexes[item.information] = i
+	}
+	heap.Init(pruner.words)
+}
+
+// wordHeapItem is a word held by a wordHeap
+type wordHeapItem struct {
+	word        string
+	information *WordInformation
+}
+
+// wordHeap is a min-heap of words by count, implementing heap.Interface
+type wordHeap struct {
+	items   []*wordHeapItem
+	indexes map[*WordInformation]int
+}
+
+func (words *wordHeap) Len() int {
+	return len(words.items)
+}
+
+func (words *wordHeap) Less(i, j int) bool {
+	return words.items[i].information.Count < words.items[j].information.Count
+}
+
+func (words *wordHeap) Swap(i, j int) {
+	words.items[i], words.items[j] = words.items[j], words.items[i]
+	words.indexes[words.items[i].information] = i
+	words.indexes[words.items[j].information] = j
+}
+
+func (words *wordHeap) Push(x interface{}) {
+	item := x.(*wordHeapItem)
+	words.indexes[item.information] = len(words.items)
+	words.items = append(words.items, item)
+}
+
+func (words *wordHeap) Pop() interface{} {
+	item := words.items[len(words.items)-1]
+	words.items = words.items[:len(words.items)-1]
+	delete(words.indexes, item.information)
+	return item
+}
+
+// update restores the order of the heap after the count of a word increased
+func (words *wordHeap) update(information *WordInformation) {
+	if index, found := words.indexes[information]; found {
+		heap.Fix(words, index)
+	}
+}
+
+// remove removes a word from the heap
+func (words *wordHeap) remove(information *WordInformation) {
+	if index, found := words.indexes[information]; found {
+		heap.Remove(words, index)
+	}
+}
+
+// countMinSketch estimates the counts of words with a fixed memory. The estimates are never lower than
+// the real counts
+type countMinSketch struct {
+	counts [][]int
+}
+
+// createCountMinSketch creates a new sketch with depth rows of width counters
+func createCountMinSketch(depth int, width int) *countMinSketch {
+	counts := make([][]int, depth)
+	for i := range counts {
+		counts[i] = make([]int, width)
+	}
+	return &countMinSketch{counts: counts}
+}
+
+// add adds the count to the word and returns its new estimated count
+func (sketch *countMinSketch) add(word string, count int) int {
+	estimate := -1
+	for row, counts := range sketch.counts {
+		column := sketch.getColumn(word, row)
+		counts[column] += count
+		if estimate < 0 || counts[column] < estimate {
+			estimate = counts[column]
+		}
+	}
+	return estimate
+}
+
+// getColumn returns the counter of the word in the given row
+func (sketch *countMinSketch) getColumn(word string, row int) int {
+	hash := fnv.New64a()
+	hash.Write([]byte{byte(row)})
+	hash.Write([]byte(word))
+	return int(hash.Sum64() % uint64(len(sketch.counts[row])))
+}
@@ -0,0 +1,107 @@
+package levenshteinsearch
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPrune(t *testing.T) {
+
+	dict := createCountedDictionary(map[string]int{"rabbit": 3, "rabbits": 1, "rabit": 1, "alice": 2, "alcie": 1})
+
+	if removed := dict.Prune(2); removed != 3 {
+		t.Errorf("Expected 3 words to be removed, found %v", removed)
+	}
+
+	checkDictionary(t, "Prune", dict, map[string]int{"rabbit": 3, "alice": 2})
+	if len(dict.Root.children['a'].children['l'].children) != 1 {
+		t.Error("Expected the dead branches to be removed")
+	}
+	if len(dict.SearchAll("rabit", 1)) != 1 {
+		t.Error("Expected only 'rabbit' to be found")
+	}
+}
+
+func TestKeepTop(t *testing.T) {
+
+	dict := createCountedDictionary(map[string]int{"the": 10, "rabbit": 3, "alice": 3, "queen": 3, "hatter": 1})
+
+	if removed := dict.KeepTop(3); removed != 2 {
+		t.Errorf("Expected 2 words to be removed, found %v", removed)
+	}
+	checkDictionary(t, "KeepTop", dict, map[string]int{"the": 10, "alice": 3, "queen": 3})
+
+	if dict.KeepTop(5) != 0 || dict.KeepTop(0) != 3 {
+		t.Error("Unexpected number of words removed")
+	}
+	checkDictionary(t, "KeepTop", dict, map[string]int{})
+	if len(dict.Root.children) != 0 {
+		t.Error("Expected all the branches to be removed")
+	}
+}
+
+func TestAutoPruneSpaceSaving(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.EnableAutoPrune(3, PruneSpaceSaving)
+
+	for i := 0; i < 10; i++ {
+		dict.Put("the")
+	}
+	for i := 0; i < 5; i++ {
+		dict.Put("rabbit")
+	}
+	dict.Put("alice")
+	dict.Put("alice")
+	dict.Put("hatter")
+
+	// "hatter" replaced "alice", inheriting its count
+	checkDictionary(t, "Space-Saving", dict, map[string]int{"the": 10, "rabbit": 5, "hatter": 3})
+
+	// The typos replace each other rather than the frequent words
+	dict.Put("typo1")
+	dict.Put("typo2")
+	checkDictionary(t, "Space-Saving", dict, map[string]int{"the": 10, "rabbit": 5, "typo2": 5})
+	if dict.WordCount != 20 {
+		t.Errorf("Expected the total count to be kept, found %v", dict.WordCount)
+	}
+
+	dict.DisableAutoPrune()
+	dict.Put("queen")
+	if dict.UniqueWordCount != 4 {
+		t.Error("Expected the dictionary to not be pruned anymore")
+	}
+}
+
+func TestAutoPruneCountMin(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.EnableAutoPrune(10, PruneCountMin)
+
+	for i := 0; i < 100; i++ {
+		dict.Put(fmt.Sprintf("typo%v", i))
+		if i%4 == 0 {
+			dict.Put("rabbit")
+		}
+	}
+
+	if dict.UniqueWordCount > 10 {
+		t.Errorf("Expected at most 10 unique words, found %v", dict.UniqueWordCount)
+	}
+	if information := dict.Get("rabbit"); information == nil || information.Count < 25 {
+		t.Errorf("Expected 'rabbit' to be kept with its full count, found %v", information)
+	}
+}
+
+func TestAutoPruneExistingWords(t *testing.T) {
+
+	dict := createCountedDictionary(map[string]int{"the": 10, "rabbit": 3, "alice": 2, "hatter": 1})
+	dict.EnableAutoPrune(2, PruneSpaceSaving)
+	checkDictionary(t, "Enable", dict, map[string]int{"the": 10, "rabbit": 3})
+
+	dict.Merge(createCountedDictionary(map[string]int{"queen": 20}))
+	checkDictionary(t, "Merge", dict, map[string]int{"the": 10, "queen": 20})
+
+	dict.Put("cat")
+	checkDictionary(t, "Put", dict, map[string]int{"queen": 20, "cat": 11})
+}
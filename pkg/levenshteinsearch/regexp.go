@@ -0,0 +1,166 @@
+package levenshteinsearch
+
+import (
+	"fmt"
+	"regexp/syntax"
+)
+
+// RegexpAutomaton is an automaton matching the words matched by a regular expression, with a maximum number
+// of edits. The regular expression follows the RE2 syntax, as used by the standard regexp package, with the
+// exception of the word boundaries \b and \B that are not supported. As for the standard package, the
+// regular expression is not anchored: "ish$" matches all the words ending with "ish". Anchoring the
+// regular expression at the beginning of the words with ^ allows the trie walk to cut the branches early.
+type RegexpAutomaton struct {
+	pattern string
+	nfa     *fuzzyNFA
+}
+
+// CreateRegexpAutomaton creates a new automaton for the given regular expression. An error is returned if
+// the regular expression is malformed or uses an unsupported feature
+func CreateRegexpAutomaton(pattern string, distanceMax int) (*RegexpAutomaton, error) {
+	regexp, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	regexp = regexp.Simplify()
+
+	program, err := syntax.Compile(regexp)
+	if err != nil {
+		return nil, err
+	}
+
+	// The regular expression is not anchored, so any number of runes can precede or follow it. The
+	// leading runes are only added if needed, as they would prevent to cut any branch of the trie
+	anyRunes := &syntax.Regexp{
+		Op:  syntax.OpStar,
+		Sub: []*syntax.Regexp{{Op: syntax.OpAnyChar}},
+	}
+	wrapped := &syntax.Regexp{
+		Op:  syntax.OpConcat,
+		Sub: []*syntax.Regexp{regexp, anyRunes},
+	}
+	if program.StartCond()&syntax.EmptyBeginText == 0 {
+		wrapped.Sub = []*syntax.Regexp{anyRunes, regexp, anyRunes}
+	}
+
+	program, err = syntax.Compile(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := compileProgram(program)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegexpAutomaton{
+		pattern: pattern,
+		nfa: &fuzzyNFA{
+			nodes:       nodes,
+			startNode:   program.Start,
+			distanceMax: distanceMax,
+		},
+	}, nil
+}
+
+// GetDistanceMax returns the maximum distance defined for this automaton
+func (automaton *RegexpAutomaton) GetDistanceMax() int {
+	return automaton.nfa.distanceMax
+}
+
+// GetPattern returns the regular expression defined for this automaton
+func (automaton *RegexpAutomaton) GetPattern() string {
+	return automaton.pattern
+}
+
+// Start gives the initial state allowing to step into the automaton
+func (automaton *RegexpAutomaton) Start() State {
+	return automaton.nfa.start()
+}
+
+// Step steps through the automaton by generating the next state based on the current one + the given
+// char.
+func (automaton *RegexpAutomaton) Step(state State, character rune) State {
+	return automaton.nfa.step(state.(nfaState), character)
+}
+
+// IsMatch returns true if the given states is matching
+func (automaton *RegexpAutomaton) IsMatch(state State) bool {
+	return automaton.nfa.isMatch(state.(nfaState))
+}
+
+// CanMatch returns true if the given states can match
+func (automaton *RegexpAutomaton) CanMatch(state State) bool {
+	return automaton.nfa.canMatch(state.(nfaState))
+}
+
+// SearchRegexp returns all the words of the dictionary matched by the regular expression with at most
+// distanceMax edits. See RegexpAutomaton for the supported syntax.
+func (dictionary *Dictionary) SearchRegexp(pattern string, distanceMax int) (map[string]*WordInformation, error) {
+	automaton, err := CreateRegexpAutomaton(pattern, distanceMax)
+	if err != nil {
+		return nil, err
+	}
+
+	return dictionary.Search(automaton), nil
+}
+
+// compileProgram converts the instructions of a compiled regular expression to the nodes of a non
+// deterministic automaton. Each instruction gives the node having the same index.
+func compileProgram(program *syntax.Prog) ([]nfaNode, error) {
+	nodes := make([]nfaNode, len(program.Inst))
+
+	for index := range program.Inst {
+		instruction := &program.Inst[index]
+		node := &nodes[index]
+
+		switch instruction.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			node.epsilons = []int{int(instruction.Out), int(instruction.Arg)}
+		case syntax.InstCapture, syntax.InstNop:
+			node.epsilons = []int{int(instruction.Out)}
+		case syntax.InstEmptyWidth:
+			condition, err := convertEmptyWidth(syntax.EmptyOp(instruction.Arg))
+			if err != nil {
+				return nil, err
+			}
+			node.epsilons = []int{int(instruction.Out)}
+			node.condition = condition
+		case syntax.InstMatch:
+			node.final = true
+		case syntax.InstFail:
+			// Nothing to do, the node leads nowhere
+		case syntax.InstRune, syntax.InstRune1:
+			node.accept = instruction.MatchRune
+			node.next = int(instruction.Out)
+		case syntax.InstRuneAny:
+			node.accept = acceptAny
+			node.next = int(instruction.Out)
+		case syntax.InstRuneAnyNotNL:
+			node.accept = acceptAnyNotNewLine
+			node.next = int(instruction.Out)
+		default:
+			return nil, fmt.Errorf("unsupported instruction %v in regular expression", instruction.Op)
+		}
+	}
+
+	return nodes, nil
+}
+
+// convertEmptyWidth converts the assertion of an empty width instruction to the condition of a node. As
+// the words are matched in full, the beginning and the end of a line are the ones of the word.
+func convertEmptyWidth(operation syntax.EmptyOp) (emptyCondition, error) {
+	switch operation {
+	case syntax.EmptyBeginText, syntax.EmptyBeginLine, syntax.EmptyBeginText | syntax.EmptyBeginLine:
+		return conditionBegin, nil
+	case syntax.EmptyEndText, syntax.EmptyEndLine, syntax.EmptyEndText | syntax.EmptyEndLine:
+		return conditionEnd, nil
+	default:
+		return conditionNone, fmt.Errorf("word boundaries are not supported in regular expressions")
+	}
+}
+
+// acceptAnyNotNewLine accepts any rune except the new line
+func acceptAnyNotNewLine(character rune) bool {
+	return character != '\n'
+}
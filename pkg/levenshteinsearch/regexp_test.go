@@ -0,0 +1,89 @@
+package levenshteinsearch
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestSearchRegexp(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("british")
+	dict.Put("reddish")
+	dict.Put("fish")
+	dict.Put("fishing")
+	dict.Put("route66")
+	dict.Put("rabbit")
+
+	result, err := dict.SearchRegexp("ish$", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 3 || result["british"] == nil || result["reddish"] == nil || result["fish"] == nil {
+		t.Errorf("Expected to find 'british', 'reddish' and 'fish' with 'ish$', found %v", result)
+	}
+
+	result, err = dict.SearchRegexp(`\d`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result["route66"] == nil {
+		t.Errorf("Expected to find 'route66' with '\\d', found %v", result)
+	}
+
+	result, err = dict.SearchRegexp("^rab+it$", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result["rabbit"] == nil {
+		t.Errorf("Expected to find 'rabbit' with '^rab+it$', found %v", result)
+	}
+
+	result, err = dict.SearchRegexp("^rabit$", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result["rabbit"] == nil {
+		t.Errorf("Expected to find 'rabbit' with '^rabit$' and a distance of 1, found %v", result)
+	}
+
+	result, err = dict.SearchRegexp("^f[aeiou]sh$", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result["fish"] == nil {
+		t.Errorf("Expected to find 'fish' with '^f[aeiou]sh$', found %v", result)
+	}
+}
+
+func TestRegexpAutomatonMatchesStandardRegexp(t *testing.T) {
+
+	words := []string{"", "a", "ab", "abc", "cab", "banana", "bandana", "cabana", "ABBA"}
+	patterns := []string{"a", "^a", "a$", "^b.n", "(?i)ab", "an(an|dan)a$", "^[abc]*$", "^c?a", "x|^$"}
+
+	for _, pattern := range patterns {
+		reference := regexp.MustCompile(pattern)
+		automaton, err := CreateRegexpAutomaton(pattern, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, word := range words {
+			state := automaton.Start()
+			for _, c := range word {
+				state = automaton.Step(state, c)
+			}
+			if automaton.IsMatch(state) != reference.MatchString(word) {
+				t.Errorf("Unexpected match result for regular expression '%v' and word '%v'", pattern, word)
+			}
+		}
+	}
+}
+
+func TestRegexpErrors(t *testing.T) {
+	for _, pattern := range []string{"(abc", `\bword`, "a**"} {
+		if _, err := CreateRegexpAutomaton(pattern, 0); err == nil {
+			t.Errorf("Expected regular expression '%v' to be rejected", pattern)
+		}
+	}
+}
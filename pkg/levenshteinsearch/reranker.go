@@ -0,0 +1,125 @@
+package levenshteinsearch
+
+import (
+	"math"
+	"sort"
+)
+
+// Reranker orders the results of a search by a blend of scores, each between 0 and 1, weighted by the
+// fields of the Reranker. A weight of 0 ignores the score. The scores are:
+//   - the distance: 1 minus the normalized distance
+//   - the Jaro-Winkler similarity
+//   - the Dice and Jaccard similarities of the bigrams
+//   - the common prefix: the length of the common prefix, up to PrefixLengthMax, divided by PrefixLengthMax
+//   - the count: the logarithm of the count of the word divided by the logarithm of the highest count of the
+//     results
+type Reranker struct {
+	// DistanceWeight is the weight of the edit distance
+	DistanceWeight float64
+	// JaroWinklerWeight is the weight of the Jaro-Winkler similarity
+	JaroWinklerWeight float64
+	// DiceWeight is the weight of the Dice similarity of the bigrams
+	DiceWeight float64
+	// JaccardWeight is the weight of the Jaccard similarity of the bigrams
+	JaccardWeight float64
+	// PrefixWeight is the weight of the common prefix bonus
+	PrefixWeight float64
+	// PrefixLengthMax is the length of the common prefix giving the full bonus
+	PrefixLengthMax int
+	// CountWeight is the weight of the count of the words
+	CountWeight float64
+}
+
+// CreateDefaultReranker creates a reranker mostly based on the distance and the Jaro-Winkler similarity,
+// the bigrams, the common prefix and the count breaking the ties
+func CreateDefaultReranker() *Reranker {
+	return &Reranker{
+		DistanceWeight:    1,
+		JaroWinklerWeight: 1,
+		DiceWeight:        0.5,
+		JaccardWeight:     0,
+		PrefixWeight:      0.25,
+		PrefixLengthMax:   4,
+		CountWeight:       0.25,
+	}
+}
+
+// Rerank computes the score of each result and sorts the results by decreasing score. The results having the
+// same score keep their order.
+func (reranker *Reranker) Rerank(searchedTerm string, results []SearchResult) {
+	countMax := 0
+	for _, result := range results {
+		countMax = max(countMax, result.Information.Count)
+	}
+
+	for i := range results {
+		results[i].Score = reranker.getScore(searchedTerm, &results[i], countMax)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+}
+
+// RankWords turns words, such as the ones returned by SearchAll, into results sorted by the reranker. The
+// distance of each word is its Levenshtein distance with the searched term.
+func (reranker *Reranker) RankWords(searchedTerm string, words map[string]*WordInformation) []SearchResult {
+	results := make([]SearchResult, 0, len(words))
+	for word, information := range words {
+		distance := GetEditDistance(ComputeEditScript(searchedTerm, word, false))
+		results = append(results, SearchResult{
+			Word:               word,
+			Information:        information,
+			Distance:           distance,
+			Cost:               float64(distance),
+			NormalizedDistance: GetNormalizedDistance(searchedTerm, word, distance),
+		})
+	}
+
+	sortSearchResults(results, false)
+	reranker.Rerank(searchedTerm, results)
+
+	return results
+}
+
+// getScore returns the blended score of a single result
+func (reranker *Reranker) getScore(searchedTerm string, result *SearchResult, countMax int) float64 {
+	total := 0.0
+	weights := 0.0
+	add := func(weight float64, score func() float64) {
+		if weight != 0 {
+			total += weight * score()
+			weights += weight
+		}
+	}
+
+	add(reranker.DistanceWeight, func() float64 {
+		return 1 - result.NormalizedDistance
+	})
+	add(reranker.JaroWinklerWeight, func() float64 {
+		return JaroWinklerSimilarity(searchedTerm, result.Word)
+	})
+	add(reranker.DiceWeight, func() float64 {
+		return BigramDiceSimilarity(searchedTerm, result.Word)
+	})
+	add(reranker.JaccardWeight, func() float64 {
+		return BigramJaccardSimilarity(searchedTerm, result.Word)
+	})
+	add(reranker.PrefixWeight, func() float64 {
+		if reranker.PrefixLengthMax <= 0 {
+			return 0
+		}
+		return float64(min(CommonPrefixLength(searchedTerm, result.Word), reranker.PrefixLengthMax)) / float64(reranker.PrefixLengthMax)
+	})
+	add(reranker.CountWeight, func() float64 {
+		if countMax <= 1 {
+			return 1
+		}
+		return math.Log(float64(result.Information.Count)) / math.Log(float64(countMax))
+	})
+
+	if weights == 0 {
+		return 0
+	}
+	return total / weights
+}
@@ -0,0 +1,77 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestRerankerScores(t *testing.T) {
+
+	dict := CreateDictionary()
+	for _, word := range []string{"martha", "marhta", "artha", "marth", "mxrtha"} {
+		dict.Put(word)
+	}
+
+	results := dict.SearchWithOptions("martha", SearchOptions{
+		DistanceMax: 2,
+		Reranker:    CreateDefaultReranker(),
+	})
+
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 results, found %v", len(results))
+	}
+	if results[0].Word != "martha" || results[0].Score != 1 {
+		t.Errorf("Expected 'martha' to come first with a score of 1, found '%v' with %v", results[0].Word, results[0].Score)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score || results[i].Score < 0 {
+			t.Errorf("Expected decreasing scores between 0 and 1, found %v after %v", results[i].Score, results[i-1].Score)
+		}
+	}
+
+	// "marth" shares the full prefix, unlike "artha", for the same distance
+	if getResultPosition(results, "marth") > getResultPosition(results, "artha") {
+		t.Error("Expected 'marth' to be ranked before 'artha'")
+	}
+}
+
+func TestRerankerCount(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("alan")
+	dict.Put("alain")
+	dict.Put("alain")
+	dict.Put("alain")
+	dict.Put("allan")
+
+	reranker := &Reranker{CountWeight: 1}
+	results := reranker.RankWords("alan", dict.SearchAll("alan", 1))
+
+	if len(results) != 3 || results[0].Word != "alain" {
+		t.Fatalf("Expected 'alain' to come first by its count, found %v", results)
+	}
+	// Same score: the order by distance, then alphabetically, is kept
+	if results[1].Word != "alan" || results[2].Word != "allan" {
+		t.Errorf("Expected the ties to keep the order of the distance, found '%v' and '%v'", results[1].Word, results[2].Word)
+	}
+	if results[2].Distance != 1 {
+		t.Errorf("Expected 'allan' to have a distance of 1, found %v", results[2].Distance)
+	}
+}
+
+func TestRerankerWithoutWeight(t *testing.T) {
+
+	reranker := &Reranker{}
+	results := []SearchResult{{Word: "b", Information: &WordInformation{Count: 1}}, {Word: "a", Information: &WordInformation{Count: 1}}}
+	reranker.Rerank("a", results)
+
+	if results[0].Word != "b" || results[0].Score != 0 {
+		t.Error("Expected a reranker without weight to keep the order")
+	}
+}
+
+func getResultPosition(results []SearchResult, word string) int {
+	for i, result := range results {
+		if result.Word == word {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,329 @@
+package levenshteinsearch
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+)
+
+// OverlapPolicy defines how the overlapping occurrences found by a TextScanner are handled. In all cases, the
+// occurrences of the same word overlapping each other are reduced to the best one.
+type OverlapPolicy int
+
+const (
+	// OverlapKeepAll keeps the overlapping occurrences of different words
+	OverlapKeepAll OverlapPolicy = iota
+	// OverlapKeepBest only keeps non overlapping occurrences, preferring the ones with the lowest distance,
+	// then the longest ones, then the first ones
+	OverlapKeepBest
+)
+
+// TextMatch is an approximate occurrence of a word of the dictionary in a text
+type TextMatch struct {
+	// Start is the offset in bytes of the occurrence in the text
+	Start int
+	// End is the offset in bytes following the occurrence in the text
+	End int
+	// Word is the word of the dictionary found
+	Word string
+	// Distance is the Levenshtein distance between the word and the text of the occurrence
+	Distance int
+	// Information is the information of the word found
+	Information *WordInformation
+}
+
+// TextScanner finds the approximate occurrences of the words of a dictionary in a running text
+type TextScanner struct {
+	dictionary  *Dictionary
+	distanceMax int
+	overlap     OverlapPolicy
+}
+
+// CreateTextScanner creates a new scanner finding the words of the dictionary with at most distanceMax
+// edits. To avoid meaningless occurrences, a word is only found if the distance is lower than its length.
+func CreateTextScanner(dictionary *Dictionary, distanceMax int, overlap OverlapPolicy) *TextScanner {
+	return &TextScanner{
+		dictionary:  dictionary,
+		distanceMax: distanceMax,
+		overlap:     overlap,
+	}
+}
+
+// Scan reads the text from the reader and returns all the occurrences found, sorted by position. The text
+// is read through a buffer holding the longest portion that can match a word, so that a long text never
+// needs to be fully in memory.
+func (scanner *TextScanner) Scan(reader io.Reader) ([]TextMatch, error) {
+	walker := createTextWalker(scanner.dictionary, nil, scanner.distanceMax)
+	runeReader := bufio.NewReader(reader)
+
+	// The buffered runes, with their offset in bytes in the text
+	runes := make([]rune, 0, walker.window+1)
+	offsets := make([]int, 0, walker.window+1)
+	offset := 0
+	ended := false
+
+	selection := createMatchSelection(scanner.overlap)
+	for {
+		// Fill the buffer up to the portion that can match a word
+		for !ended && len(runes) < walker.window {
+			r, size, err := runeReader.ReadRune()
+			if err == io.EOF {
+				ended = true
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			runes = append(runes, r)
+			offsets = append(offsets, offset)
+			offset += size
+		}
+		if len(runes) == 0 {
+			break
+		}
+
+		// For each word, only keep the best end for this start
+		bestByWord := make(map[string]TextMatch)
+		walker.text = runes
+		walker.walk(0, func(word string, wordLength int, information *WordInformation, end int, distance int) {
+			if distance >= wordLength {
+				return
+			}
+
+			endOffset := offset
+			if end < len(offsets) {
+				endOffset = offsets[end]
+			}
+			candidate := TextMatch{
+				Start:       offsets[0],
+				End:         endOffset,
+				Word:        word,
+				Distance:    distance,
+				Information: information,
+			}
+
+			previous, found := bestByWord[word]
+			if !found || isBetterMatch(candidate, previous) {
+				bestByWord[word] = candidate
+			}
+		})
+
+		candidates := make([]TextMatch, 0, len(bestByWord))
+		for _, candidate := range bestByWord {
+			candidates = append(candidates, candidate)
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].End != candidates[j].End {
+				return candidates[i].End < candidates[j].End
+			}
+			return candidates[i].Word < candidates[j].Word
+		})
+		for _, candidate := range candidates {
+			selection.add(candidate)
+		}
+
+		runes = runes[1:]
+		offsets = offsets[1:]
+	}
+
+	return selection.getMatches(), nil
+}
+
+// ScanString returns all the occurrences found in the text, sorted by position
+func (scanner *TextScanner) ScanString(text string) []TextMatch {
+	// Reading a string never fails
+	matches, _ := scanner.Scan(strings.NewReader(text))
+	return matches
+}
+
+// matchSelection keeps the occurrences not overlapping with a better one. The occurrences must be added by
+// increasing start, so that an occurrence can only overlap the last one kept: the ones kept before end
+// before it starts.
+type matchSelection struct {
+	overlap  OverlapPolicy
+	selected []TextMatch
+	// lastByWord is the index of the last occurrence kept for each word
+	lastByWord map[string]int
+}
+
+// createMatchSelection creates a new empty selection
+func createMatchSelection(overlap OverlapPolicy) *matchSelection {
+	return &matchSelection{
+		overlap:    overlap,
+		selected:   make([]TextMatch, 0),
+		lastByWord: make(map[string]int),
+	}
+}
+
+// add adds an occurrence, starting after or with all the ones already added. If it overlaps the last
+// occurrence kept, only the best of the two is kept
+func (selection *matchSelection) add(candidate TextMatch) {
+	last := len(selection.selected) - 1
+	if selection.overlap == OverlapKeepAll {
+		index, found := selection.lastByWord[candidate.Word]
+		if !found {
+			index = -1
+		}
+		last = index
+	}
+
+	if last >= 0 && candidate.Start < selection.selected[last].End {
+		if isBetterMatch(candidate, selection.selected[last]) {
+			selection.selected[last] = candidate
+			selection.lastByWord[candidate.Word] = last
+		}
+		return
+	}
+
+	selection.selected = append(selection.selected, candidate)
+	selection.lastByWord[candidate.Word] = len(selection.selected) - 1
+}
+
+// getMatches returns the occurrences kept, sorted by position
+func (selection *matchSelection) getMatches() []TextMatch {
+	selected := selection.selected
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Start != selected[j].Start {
+			return selected[i].Start < selected[j].Start
+		}
+		if selected[i].End != selected[j].End {
+			return selected[i].End < selected[j].End
+		}
+		return selected[i].Word < selected[j].Word
+	})
+	return selected
+}
+
+// isBetterMatch returns true if the first match is better than the second: it has a lower distance, or it
+// is longer, or it starts before
+func isBetterMatch(first TextMatch, second TextMatch) bool {
+	if first.Distance != second.Distance {
+		return first.Distance < second.Distance
+	}
+	if (first.End - first.Start) != (second.End - second.Start) {
+		return (first.End - first.Start) > (second.End - second.Start)
+	}
+	if first.Start != second.Start {
+		return first.Start < second.Start
+	}
+	return first.Word < second.Word
+}
+
+// textWalker walks the trie of a dictionary along a text. For a starting position in the text, it computes,
+// for each node of the trie, the Levenshtein distance between the word of the node and all the portions of
+// the text beginning at this position. A branch is cut as soon as all the distances exceed the maximum.
+type textWalker struct {
+	dictionary  *Dictionary
+	text        []rune
+	distanceMax int
+	window      int
+	// rows holds the distances computed for each depth of the trie, reused from a node to the next
+	rows [][]int
+}
+
+// createTextWalker creates a new walker for the text
+func createTextWalker(dictionary *Dictionary, text []rune, distanceMax int) *textWalker {
+	return &textWalker{
+		dictionary:  dictionary,
+		text:        text,
+		distanceMax: distanceMax,
+		// A word can not match a portion of the text longer than itself plus the maximum distance
+		window: dictionary.Root.depth() + distanceMax,
+	}
+}
+
+// walk visits all the words of the dictionary matching a portion of the text beginning at start. The visit
+// function is called for each end of the portion (exclusive, in runes) giving a distance lower or equal to
+// the maximum.
+func (walker *textWalker) walk(start int, visit func(word string, wordLength int, information *WordInformation, end int, distance int)) {
+	length := len(walker.text) - start
+	if length > walker.window {
+		length = walker.window
+	}
+
+	// The distances between the empty word and the portions of the text
+	distances := walker.getRow(0, min(length, walker.distanceMax)+1)
+	for i := range distances {
+		distances[i] = i
+	}
+
+	for character, child := range walker.dictionary.Root.children {
+		walker.walkNode(child, character, "", 1, start, length, distances, visit)
+	}
+}
+
+// walkNode is the recursive part of the walk. The word of a node at a given depth can only match the portions
+// not longer than this depth plus the maximum distance, so that only these distances are computed. The
+// distances are capped just above the maximum, which is enough to tell the portions that match.
+func (walker *textWalker) walkNode(trie *RuneTrie, nodeCharacter rune, prefix string, depth int, start int, length int, previousDistances []int, visit func(word string, wordLength int, information *WordInformation, end int, distance int)) {
+
+	capped := walker.distanceMax + 1
+	distances := walker.getRow(depth, min(length, depth+walker.distanceMax)+1)
+	distances[0] = min(previousDistances[0]+1, capped)
+	best := distances[0]
+	for i := 1; i < len(distances); i++ {
+		cost := 1
+		if walker.text[start+i-1] == nodeCharacter {
+			cost = 0
+		}
+		deletion := capped
+		if i < len(previousDistances) {
+			deletion = previousDistances[i]
+		}
+		distances[i] = min(min3(deletion+1, distances[i-1]+1, previousDistances[i-1]+cost), capped)
+		best = min(best, distances[i])
+	}
+
+	// If no portion can match anymore, stop here
+	if best > walker.distanceMax {
+		return
+	}
+
+	currentWord := prefix + string(nodeCharacter)
+
+	if trie.information != nil {
+		for i := 1; i < len(distances); i++ {
+			if distances[i] <= walker.distanceMax {
+				visit(currentWord, depth, trie.information, start+i, distances[i])
+			}
+		}
+	}
+
+	for character, child := range trie.children {
+		walker.walkNode(child, character, currentWord, depth+1, start, length, distances, visit)
+	}
+}
+
+// getRow returns the row of distances of the depth, with the given size
+func (walker *textWalker) getRow(depth int, size int) []int {
+	for len(walker.rows) <= depth {
+		walker.rows = append(walker.rows, nil)
+	}
+	if cap(walker.rows[depth]) < size {
+		walker.rows[depth] = make([]int, size)
+	}
+	return walker.rows[depth][:size]
+}
+
+// depth returns the length of the longest word below the node
+func (trie *RuneTrie) depth() int {
+	deepest := 0
+	for _, child := range trie.children {
+		deepest = max(deepest, child.depth()+1)
+	}
+	return deepest
+}
+
+// min3 returns the minimum of the three values
+func min3(a, b, c int) int {
+	return min(a, min(b, c))
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	} else {
+		return b
+	}
+}
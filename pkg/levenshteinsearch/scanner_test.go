@@ -0,0 +1,133 @@
+package levenshteinsearch
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestScanText(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("rabbit")
+	dict.Put("alice")
+	dict.Put("queen")
+
+	text := "Then the white rabit ran, and Älice followed the qeen."
+
+	matches, err := CreateTextScanner(dict, 1, OverlapKeepBest).Scan(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(matches) != 3 {
+		t.Fatalf("Expected to find 3 occurrences, found %v", matches)
+	}
+
+	expected := []string{"rabit", "Älice", "qeen"}
+	words := []string{"rabbit", "alice", "queen"}
+	for i, match := range matches {
+		if text[match.Start:match.End] != expected[i] {
+			t.Errorf("Expected occurrence '%v', found '%v'", expected[i], text[match.Start:match.End])
+		}
+		if match.Word != words[i] {
+			t.Errorf("Expected word '%v', found '%v'", words[i], match.Word)
+		}
+		if match.Distance != 1 {
+			t.Errorf("Expected a distance of 1 for '%v', found %v", match.Word, match.Distance)
+		}
+	}
+}
+
+func TestScanTextOverlap(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("rabbit")
+	dict.Put("bit")
+
+	text := "rabbit"
+
+	matches := CreateTextScanner(dict, 0, OverlapKeepAll).ScanString(text)
+	if len(matches) != 2 {
+		t.Errorf("Expected to find 'rabbit' and 'bit' when keeping overlaps, found %v", matches)
+	}
+
+	matches = CreateTextScanner(dict, 0, OverlapKeepBest).ScanString(text)
+	if len(matches) != 1 || matches[0].Word != "rabbit" {
+		t.Errorf("Expected to find only 'rabbit' when keeping the best occurrences, found %v", matches)
+	}
+
+	// With a distance of 1, "rabbit" also matches "abbit", "rabbi", etc. but only the best is kept
+	matches = CreateTextScanner(dict, 1, OverlapKeepAll).ScanString(text)
+	count := 0
+	for _, match := range matches {
+		if match.Word == "rabbit" {
+			count++
+			if match.Distance != 0 || match.Start != 0 || match.End != 6 {
+				t.Errorf("Expected the exact occurrence of 'rabbit', found %v", match)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected a single occurrence of 'rabbit', found %v", matches)
+	}
+}
+
+func TestScanStreamsTheText(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	dict.Prune(5)
+
+	text := strings.Repeat("Then the white rabit ran, and Älice followed the qeen. ", 20)
+
+	for _, overlap := range []OverlapPolicy{OverlapKeepAll, OverlapKeepBest} {
+		scanner := CreateTextScanner(dict, 1, overlap)
+
+		// Reading byte by byte gives the same occurrences
+		matches, err := scanner.Scan(iotest.OneByteReader(strings.NewReader(text)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(matches, scanner.ScanString(text)) {
+			t.Errorf("Expected the same occurrences when streaming the text (overlap: %v)", overlap)
+		}
+
+		for i := 1; i < len(matches); i++ {
+			if matches[i].Start < matches[i-1].Start {
+				t.Errorf("Expected the occurrences sorted by position, found %v before %v", matches[i-1], matches[i])
+			}
+			for j := 0; j < i; j++ {
+				overlapping := matches[i].Start < matches[j].End && matches[j].Start < matches[i].End
+				if overlapping && (overlap == OverlapKeepBest || matches[i].Word == matches[j].Word) {
+					t.Errorf("Unexpected overlapping occurrences %v and %v (overlap: %v)", matches[j], matches[i], overlap)
+				}
+			}
+			if text[matches[i].Start:matches[i].End] == "" {
+				t.Errorf("Unexpected empty occurrence %v", matches[i])
+			}
+		}
+	}
+
+	// A failing reader gives its error
+	if _, err := CreateTextScanner(dict, 1, OverlapKeepAll).Scan(failingReader{}); err == nil {
+		t.Error("Expected the error of the reader")
+	}
+}
+
+// failingReader is a reader always failing
+type failingReader struct{}
+
+func (reader failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("broken")
+}
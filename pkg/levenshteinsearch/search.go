@@ -1,25 +1,34 @@
 package levenshteinsearch
 
+// SearchAll returns all the words of the dictionary having a Levenshtein distance lower or equal to distanceMax
+// with the searched term
 func (dictionary *Dictionary) SearchAll(searchedTerm string, distanceMax int) map[string]*WordInformation {
 	// Create the Automaton
 	automaton := CreateAutomaton(searchedTerm, distanceMax)
 
-	// Start the search
-	state := automaton.Start()
+	return dictionary.Search(automaton)
+}
 
+// Search returns all the words of the dictionary matched by the given automaton. The trie is walked along
+// with the automaton, so that a full branch is cut as soon as the automaton can not match anymore
+func (dictionary *Dictionary) Search(automaton Automaton) map[string]*WordInformation {
 	results := map[string]*WordInformation{}
 
-	dictionary.Root.searchAll(automaton, "", nil, state, &results)
+	dictionary.Root.searchAll(automaton, "", nil, nil, func(word string, information *WordInformation, state State) {
+		results[word] = information
+	})
 
 	return results
 }
 
-func (trie *RuneTrie) searchAll(automaton *LevenshteinAutomaton, prefix string, nodeCharacter *rune, automatonState AutomatonState, results *map[string]*WordInformation) {
+// searchAll walks the trie along with the automaton and calls visit for each word matched by the automaton,
+// giving the matching state of the automaton
+func (trie *RuneTrie) searchAll(automaton Automaton, prefix string, nodeCharacter *rune, automatonState State, visit func(word string, information *WordInformation, state State)) {
 
-	var newState AutomatonState
-	currentWord := ""
+	var newState State
+	currentWord := prefix
 
-	// The first character will be null for the root
+	// The first character will be null for the node starting the search
 	if nodeCharacter != nil {
 		// Add the given char to the state
 		newState = automaton.Step(automatonState, *nodeCharacter)
@@ -31,16 +40,21 @@ func (trie *RuneTrie) searchAll(automaton *LevenshteinAutomaton, prefix string,
 		// Compute the current word
 		currentWord = prefix + string(*nodeCharacter)
 
-		// If the node is a word and if the state is a match, add it to the result
+		// If the node is a word and if the state is a match, visit it
 		if (trie.information != nil) && automaton.IsMatch(newState) {
-			(*results)[currentWord] = trie.information
+			visit(currentWord, trie.information, newState)
 		}
 	} else {
 		newState = automaton.Start()
+
+		// The node starting the search may also be a word
+		if (trie.information != nil) && automaton.IsMatch(newState) {
+			visit(currentWord, trie.information, newState)
+		}
 	}
 
 	// Do the children
 	for character, child := range trie.children {
-		child.searchAll(automaton, currentWord, &character, newState, results)
+		child.searchAll(automaton, currentWord, &character, newState, visit)
 	}
 }
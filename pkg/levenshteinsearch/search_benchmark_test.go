@@ -212,6 +212,27 @@ func BenchmarkOptimized3Word(b *testing.B) {
 	}
 }
 
+func BenchmarkOptimizedPrefix1Word(b *testing.B) {
+
+	if err := ensureAlice(); err != nil {
+		log.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for i := 0; i < maxSimilaritySearch; i++ {
+			dict.SearchWithOptions("rabbit", SearchOptions{DistanceMax: i, PrefixLength: 2})
+		}
+	}
+}
+
 func distanceNaive(data []string, wordToSearch string, distanceMax int) map[string]*WordInformation {
 	result := map[string]*WordInformation{}
 
@@ -34,3 +34,122 @@ func TestSearch(t *testing.T) {
 		t.Error("Expected to find 'banana', 'orange' and 'monkey' with a distance of 6")
 	}
 }
+
+// prefixAutomaton is a minimal Automaton matching all the words starting with a given prefix. Its
+// state is simply the number of runes of the prefix already matched, -1 meaning no match possible
+type prefixAutomaton struct {
+	prefixRunes []rune
+}
+
+func (automaton *prefixAutomaton) Start() State {
+	return 0
+}
+
+func (automaton *prefixAutomaton) Step(state State, character rune) State {
+	matched := state.(int)
+	if matched < 0 || matched == len(automaton.prefixRunes) {
+		return matched
+	}
+	if automaton.prefixRunes[matched] != character {
+		return -1
+	}
+	return matched + 1
+}
+
+func (automaton *prefixAutomaton) IsMatch(state State) bool {
+	return state.(int) == len(automaton.prefixRunes)
+}
+
+func (automaton *prefixAutomaton) CanMatch(state State) bool {
+	return state.(int) >= 0
+}
+
+func TestSearchCustomAutomaton(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("banana")
+	dict.Put("bandana")
+	dict.Put("orange")
+
+	result := dict.Search(&prefixAutomaton{prefixRunes: []rune("ban")})
+	if len(result) != 2 || result["banana"] == nil || result["bandana"] == nil {
+		t.Error("Expected to find 'banana' and 'bandana' with the prefix 'ban'")
+	}
+
+	result = dict.Search(CreateAutomaton("banan", 1))
+	if len(result) != 1 || result["banana"] == nil {
+		t.Error("Expected to find 'banana' with a Levenshtein automaton as a generic Automaton")
+	}
+}
+
+func TestSearchWithOptions(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("banana")
+	dict.Put("bandana")
+	dict.Put("bandana")
+	dict.Put("cabana")
+	dict.Put("orange")
+
+	results := dict.SearchWithOptions("banana", SearchOptions{DistanceMax: 2})
+	if len(results) != 3 {
+		t.Fatalf("Expected to find 3 words, found %v", results)
+	}
+	if results[0].Word != "banana" || results[0].Distance != 0 {
+		t.Errorf("Expected 'banana' first with a distance of 0, found %v", results[0])
+	}
+	if results[1].Word != "bandana" || results[1].Distance != 1 {
+		t.Errorf("Expected 'bandana' second with a distance of 1, found %v", results[1])
+	}
+	if results[2].Word != "cabana" || results[2].Distance != 2 {
+		t.Errorf("Expected 'cabana' third with a distance of 2, found %v", results[2])
+	}
+	if results[0].EditScript != nil {
+		t.Error("Expected no edit script if not requested")
+	}
+
+	results = dict.SearchWithOptions("banana", SearchOptions{DistanceMax: 1, WithEditScript: true})
+	if len(results) != 2 || GetEditDistance(results[1].EditScript) != 1 {
+		t.Errorf("Expected the edit script of 'bandana' to have a single edit, found %v", results)
+	}
+}
+
+func TestSearchWithPrefixLength(t *testing.T) {
+
+	dict := CreateDictionary()
+
+	dict.Put("banana")
+	dict.Put("cabana")
+	dict.Put("bandana")
+	dict.Put("ban")
+
+	results := dict.SearchWithOptions("banana", SearchOptions{DistanceMax: 3})
+	if len(results) != 4 {
+		t.Errorf("Expected to find all the words without prefix, found %v", results)
+	}
+
+	results = dict.SearchWithOptions("banana", SearchOptions{DistanceMax: 3, PrefixLength: 2, WithEditScript: true})
+	if len(results) != 3 {
+		t.Errorf("Expected to find 'banana', 'bandana' and 'ban' with an exact prefix of 2, found %v", results)
+	}
+	for _, result := range results {
+		if result.Word == "cabana" {
+			t.Error("Expected 'cabana' to be excluded by the exact prefix")
+		}
+		if GetEditDistance(result.EditScript) != result.Distance {
+			t.Errorf("Expected the edit script of '%v' to be coherent with its distance", result.Word)
+		}
+	}
+
+	results = dict.SearchWithOptions("ban", SearchOptions{DistanceMax: 0, PrefixLength: 3})
+	if len(results) != 1 || results[0].Word != "ban" {
+		t.Errorf("Expected to find 'ban' when the prefix is the full term, found %v", results)
+	}
+
+	results = dict.SearchWithOptions("xanana", SearchOptions{DistanceMax: 3, PrefixLength: 1})
+	if len(results) != 0 {
+		t.Errorf("Expected to find nothing with an unknown prefix, found %v", results)
+	}
+}
@@ -0,0 +1,207 @@
+package levenshteinsearch
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Segmenter finds the most probable split of a text into words of a dictionary. Each segment may be an
+// approximate occurrence of a word, so that "aliceinwonderlnd" is split as "alice in wonderland". The
+// spaces of the text are only taken as hints: the tokens they separate may be merged into a single word,
+// so that "rab bit" is corrected as "rabbit".
+//
+// The probability of a segmentation is the product of the probabilities of its words, given by their count
+// in the dictionary. The costs are the opposite of the logarithms of the probabilities, lower being better.
+type Segmenter struct {
+	dictionary *Dictionary
+	// DistanceMax is the maximum Levenshtein distance between a segment and the word it stands for. A word
+	// is only used if the distance is lower than its length
+	DistanceMax int
+	// EditCost is the cost added for each edit between a segment and its word
+	EditCost float64
+	// UnknownCost is the cost added for each rune not belonging to any word
+	UnknownCost float64
+	// SplitCost is the cost of splitting the text where there is no space
+	SplitCost float64
+	// MergeCost is the cost of removing a space to merge two tokens
+	MergeCost float64
+}
+
+// Segment is a portion of a segmented text
+type Segment struct {
+	// Text is the portion of the text, without the surrounding spaces
+	Text string
+	// Word is the word of the dictionary standing for the portion, or an empty string if the portion is
+	// not a word
+	Word string
+	// Distance is the Levenshtein distance between the portion, without its spaces, and the word
+	Distance int
+	// Information is the information of the word, or nil if the portion is not a word
+	Information *WordInformation
+}
+
+// Segmentation is the result of the segmentation of a text
+type Segmentation struct {
+	// Segments are the successive portions of the text
+	Segments []Segment
+	// Distance is the total distance between the segments and their words
+	Distance int
+	// Cost is the total cost of the segmentation
+	Cost float64
+}
+
+// Default costs of the segmenter. An edit divides the probability by about 50 and an unknown rune by about
+// 20000
+const (
+	defaultSegmentEditCost    = 4
+	defaultSegmentUnknownCost = 10
+	defaultSegmentSplitCost   = 1
+	defaultSegmentMergeCost   = 1
+)
+
+// CreateSegmenter creates a new segmenter using the words of the dictionary and their counts
+func CreateSegmenter(dictionary *Dictionary, distanceMax int) *Segmenter {
+	return &Segmenter{
+		dictionary:  dictionary,
+		DistanceMax: distanceMax,
+		EditCost:    defaultSegmentEditCost,
+		UnknownCost: defaultSegmentUnknownCost,
+		SplitCost:   defaultSegmentSplitCost,
+		MergeCost:   defaultSegmentMergeCost,
+	}
+}
+
+// segmentStep is the best way found to reach a position of the text
+type segmentStep struct {
+	cost        float64
+	start       int
+	word        string
+	distance    int
+	information *WordInformation
+}
+
+// Segment returns the most probable segmentation of the text
+func (segmenter *Segmenter) Segment(text string) Segmentation {
+
+	// Remove the spaces, but remember where they were
+	originalRunes := []rune(text)
+	runes := make([]rune, 0, len(originalRunes))
+	positions := make([]int, 0, len(originalRunes)+1)
+	spaceBefore := make([]bool, 0, len(originalRunes)+1)
+	space := false
+	for i, r := range originalRunes {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		runes = append(runes, r)
+		positions = append(positions, i)
+		spaceBefore = append(spaceBefore, space)
+		space = false
+	}
+	positions = append(positions, len(originalRunes))
+	spaceBefore = append(spaceBefore, true)
+
+	// steps[i] is the best segmentation of the first i runes
+	steps := make([]segmentStep, len(runes)+1)
+	for i := 1; i < len(steps); i++ {
+		steps[i].cost = math.Inf(1)
+	}
+
+	wordCount := float64(max(segmenter.dictionary.WordCount, 1))
+	walker := createTextWalker(segmenter.dictionary, runes, segmenter.DistanceMax)
+
+	for start := 0; start < len(runes); start++ {
+		if math.IsInf(steps[start].cost, 1) {
+			continue
+		}
+
+		startCost := steps[start].cost
+		if start > 0 && !spaceBefore[start] {
+			startCost += segmenter.SplitCost
+		}
+
+		relax := func(end int, step segmentStep) {
+			for i := start + 1; i < end; i++ {
+				if spaceBefore[i] {
+					step.cost += segmenter.MergeCost
+				}
+			}
+			if step.cost < steps[end].cost {
+				steps[end] = step
+			}
+		}
+
+		// The rune may always be left alone as an unknown portion
+		relax(start+1, segmentStep{
+			cost:  startCost - math.Log(1/wordCount) + segmenter.UnknownCost,
+			start: start,
+		})
+
+		walker.walk(start, func(word string, wordLength int, information *WordInformation, end int, distance int) {
+			if distance >= wordLength {
+				return
+			}
+			relax(end, segmentStep{
+				cost:        startCost - math.Log(float64(information.Count)/wordCount) + float64(distance)*segmenter.EditCost,
+				start:       start,
+				word:        word,
+				distance:    distance,
+				information: information,
+			})
+		})
+	}
+
+	// Walk back the best steps, merging the consecutive unknown runes
+	segments := make([]Segment, 0)
+	distance := 0
+	for end := len(runes); end > 0; {
+		step := steps[end]
+		start := step.start
+		if step.information == nil {
+			for start > 0 && steps[start].information == nil && !spaceBefore[start] {
+				start = steps[start].start
+			}
+		}
+
+		segments = append(segments, Segment{
+			Text:        strings.TrimSpace(string(originalRunes[positions[start]:positions[end]])),
+			Word:        step.word,
+			Distance:    step.distance,
+			Information: step.information,
+		})
+		distance += step.distance
+		end = start
+	}
+
+	for left, right := 0, len(segments)-1; left < right; left, right = left+1, right-1 {
+		segments[left], segments[right] = segments[right], segments[left]
+	}
+
+	return Segmentation{
+		Segments: segments,
+		Distance: distance,
+		Cost:     steps[len(runes)].cost,
+	}
+}
+
+// String returns the corrected text: the words of the segments separated by a space, the portions that are
+// not words being kept as they are
+func (segmentation Segmentation) String() string {
+	words := make([]string, 0, len(segmentation.Segments))
+	for _, segment := range segmentation.Segments {
+		if segment.Information != nil {
+			words = append(words, segment.Word)
+		} else {
+			words = append(words, segment.Text)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Segment returns the most probable segmentation of the text into words of the dictionary, each segment
+// being at most at distanceMax of its word
+func (dictionary *Dictionary) Segment(text string, distanceMax int) Segmentation {
+	return CreateSegmenter(dictionary, distanceMax).Segment(text)
+}
@@ -0,0 +1,81 @@
+package levenshteinsearch
+
+import "testing"
+
+func createSegmentDictionary() *Dictionary {
+	dict := CreateDictionary()
+	for _, word := range []string{"alice", "in", "in", "in", "wonderland", "the", "the", "the", "the", "white", "rabbit", "a", "a", "bit", "rab"} {
+		dict.Put(word)
+	}
+	return dict
+}
+
+func TestSegmentExact(t *testing.T) {
+
+	segmentation := createSegmentDictionary().Segment("aliceinwonderland", 0)
+
+	if segmentation.String() != "alice in wonderland" {
+		t.Errorf("Expected 'alice in wonderland', found '%v'", segmentation.String())
+	}
+	if segmentation.Distance != 0 || len(segmentation.Segments) != 3 {
+		t.Errorf("Unexpected segmentation: %v", segmentation)
+	}
+	if segmentation.Segments[1].Text != "in" || segmentation.Segments[1].Information.Count != 3 {
+		t.Errorf("Unexpected segment: %v", segmentation.Segments[1])
+	}
+}
+
+func TestSegmentFuzzy(t *testing.T) {
+
+	segmentation := createSegmentDictionary().Segment("thewhiterabit", 1)
+
+	if segmentation.String() != "the white rabbit" {
+		t.Errorf("Expected 'the white rabbit', found '%v'", segmentation.String())
+	}
+	if segmentation.Distance != 1 || segmentation.Segments[2].Text != "rabit" {
+		t.Errorf("Unexpected segmentation: %v", segmentation)
+	}
+}
+
+func TestSegmentMerge(t *testing.T) {
+
+	dict := createSegmentDictionary()
+	segmentation := dict.Segment("the rab bit", 0)
+	if segmentation.String() != "the rabbit" {
+		t.Errorf("Expected 'the rabbit', found '%v'", segmentation.String())
+	}
+
+	segmenter := CreateSegmenter(dict, 0)
+	segmenter.MergeCost = 10
+	segmentation = segmenter.Segment("the rab bit")
+	if segmentation.String() != "the rab bit" {
+		t.Errorf("Expected the tokens to be kept with a high merge cost, found '%v'", segmentation.String())
+	}
+
+	segmenter = CreateSegmenter(dict, 1)
+	segmenter.SplitCost = 0
+	segmenter.MergeCost = 0
+	segmentation = segmenter.Segment("the whi te rab bit")
+	if segmentation.String() != "the white rabbit" {
+		t.Errorf("Expected 'the white rabbit', found '%v'", segmentation.String())
+	}
+	if segmentation.Segments[1].Text != "whi te" {
+		t.Errorf("Expected the merged segment to keep its original text, found '%v'", segmentation.Segments[1].Text)
+	}
+}
+
+func TestSegmentUnknown(t *testing.T) {
+
+	segmentation := createSegmentDictionary().Segment("thexyzrabbit", 0)
+
+	if segmentation.String() != "the xyz rabbit" {
+		t.Errorf("Expected 'the xyz rabbit', found '%v'", segmentation.String())
+	}
+	if segmentation.Segments[1].Information != nil || segmentation.Segments[1].Word != "" {
+		t.Errorf("Expected 'xyz' to be unknown, found %v", segmentation.Segments[1])
+	}
+
+	if len(createSegmentDictionary().Segment("", 1).Segments) != 0 {
+		t.Error("Expected an empty text to have no segment")
+	}
+}
@@ -0,0 +1,191 @@
+package levenshteinsearch
+
+import (
+	"sort"
+)
+
+// WordChange is the change of the count of a word between two dictionaries
+type WordChange struct {
+	// Word is the word changed
+	Word string
+	// OldCount is the count of the word in the first dictionary, 0 if it was absent
+	OldCount int
+	// NewCount is the count of the word in the second dictionary, 0 if it is absent
+	NewCount int
+}
+
+// DictionaryDiff gives the differences between two dictionaries. The words are sorted alphabetically
+type DictionaryDiff struct {
+	// Added are the words only present in the second dictionary
+	Added []WordChange
+	// Removed are the words only present in the first dictionary
+	Removed []WordChange
+	// Changed are the words present in both dictionaries with different counts
+	Changed []WordChange
+}
+
+// Merge adds all the words of the other dictionary to this one, the counts of the words present in both
+// being added. The other dictionary is left untouched. If the dictionary is pruned automatically, the least
+// frequent words are then removed to respect the limit.
+func (dictionary *Dictionary) Merge(other *Dictionary) {
+	dictionary.mergeNode(&dictionary.Root, &other.Root, "")
+	dictionary.refreshAutoPrune()
+}
+
+// Subtract removes the counts of the words of the other dictionary from this one. The words whose count
+// falls to 0 or lower are removed. The other dictionary is left untouched.
+func (dictionary *Dictionary) Subtract(other *Dictionary) {
+	dictionary.subtractNode(&dictionary.Root, &other.Root, "")
+	dictionary.refreshAutoPrune()
+}
+
+// Intersect only keeps the words also present in the other dictionary, with the lowest of their two counts.
+// The other dictionary is left untouched.
+func (dictionary *Dictionary) Intersect(other *Dictionary) {
+	dictionary.intersectNode(&dictionary.Root, &other.Root, "")
+	dictionary.refreshAutoPrune()
+}
+
+// Diff returns the words added, removed and changed to go from this dictionary to the other one
+func (dictionary *Dictionary) Diff(other *Dictionary) DictionaryDiff {
+	diff := DictionaryDiff{
+		Added:   make([]WordChange, 0),
+		Removed: make([]WordChange, 0),
+		Changed: make([]WordChange, 0),
+	}
+
+	diffNode(&dictionary.Root, &other.Root, "", &diff)
+
+	for _, changes := range [][]WordChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool {
+			return changes[i].Word < changes[j].Word
+		})
+	}
+
+	return diff
+}
+
+// mergeNode adds the words below the other node to the node
+func (dictionary *Dictionary) mergeNode(node *RuneTrie, otherNode *RuneTrie, prefix string) {
+	if otherNode.information != nil {
+		count := otherNode.information.Count
+		if node.information == nil {
+			node.information = &WordInformation{Count: count}
+			dictionary.UniqueWordCount++
+			dictionary.indexWord(prefix)
+			dictionary.countsOutdated = true
+		} else {
+			node.information.Count += count
+		}
+		dictionary.WordCount += count
+	}
+
+	for character, otherChild := range otherNode.children {
+		child := node.children[character]
+		if child == nil {
+			child = NewRuneTrie()
+			node.children[character] = child
+		}
+		dictionary.mergeNode(child, otherChild, prefix+string(character))
+	}
+}
+
+// subtractNode removes the counts of the words below the other node from the node
+func (dictionary *Dictionary) subtractNode(node *RuneTrie, otherNode *RuneTrie, prefix string) {
+	if node.information != nil && otherNode.information != nil {
+		if node.information.Count > otherNode.information.Count {
+			node.information.Count -= otherNode.information.Count
+			dictionary.WordCount -= otherNode.information.Count
+		} else {
+			dictionary.removeInformation(node, prefix)
+		}
+	}
+
+	for character, otherChild := range otherNode.children {
+		child := node.children[character]
+		if child == nil {
+			continue
+		}
+		dictionary.subtractNode(child, otherChild, prefix+string(character))
+		if child.isEmpty() {
+			delete(node.children, character)
+		}
+	}
+}
+
+// intersectNode removes the words below the node that are not below the other node
+func (dictionary *Dictionary) intersectNode(node *RuneTrie, otherNode *RuneTrie, prefix string) {
+	if node.information != nil {
+		if otherNode.information == nil {
+			dictionary.removeInformation(node, prefix)
+		} else if otherNode.information.Count < node.information.Count {
+			dictionary.WordCount -= node.information.Count - otherNode.information.Count
+			node.information.Count = otherNode.information.Count
+		}
+	}
+
+	for character, child := range node.children {
+		otherChild := otherNode.children[character]
+		if otherChild == nil {
+			// The full branch is removed
+			otherChild = NewRuneTrie()
+		}
+		dictionary.intersectNode(child, otherChild, prefix+string(character))
+		if child.isEmpty() {
+			delete(node.children, character)
+		}
+	}
+}
+
+// diffNode compares the words below the two nodes
+func diffNode(node *RuneTrie, otherNode *RuneTrie, prefix string, diff *DictionaryDiff) {
+	oldCount := 0
+	if node != nil && node.information != nil {
+		oldCount = node.information.Count
+	}
+	newCount := 0
+	if otherNode != nil && otherNode.information != nil {
+		newCount = otherNode.information.Count
+	}
+
+	change := WordChange{Word: prefix, OldCount: oldCount, NewCount: newCount}
+	switch {
+	case oldCount == 0 && newCount > 0:
+		diff.Added = append(diff.Added, change)
+	case oldCount > 0 && newCount == 0:
+		diff.Removed = append(diff.Removed, change)
+	case oldCount != newCount:
+		diff.Changed = append(diff.Changed, change)
+	}
+
+	if node != nil {
+		for character, child := range node.children {
+			var otherChild *RuneTrie
+			if otherNode != nil {
+				otherChild = otherNode.children[character]
+			}
+			diffNode(child, otherChild, prefix+string(character), diff)
+		}
+	}
+	if otherNode != nil {
+		for character, otherChild := range otherNode.children {
+			if node == nil || node.children[character] == nil {
+				diffNode(nil, otherChild, prefix+string(character), diff)
+			}
+		}
+	}
+}
+
+// removeInformation removes the word of the node, updating the counters and the indexes of the dictionary
+func (dictionary *Dictionary) removeInformation(node *RuneTrie, word string) {
+	dictionary.WordCount -= node.information.Count
+	dictionary.UniqueWordCount--
+	dictionary.unindexWord(word)
+	dictionary.countsOutdated = true
+	node.information = nil
+}
+
+// isEmpty returns true if the node holds no word and has no child
+func (trie *RuneTrie) isEmpty() bool {
+	return trie.information == nil && len(trie.children) == 0
+}
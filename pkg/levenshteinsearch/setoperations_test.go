@@ -0,0 +1,120 @@
+package levenshteinsearch
+
+import "testing"
+
+func createCountedDictionary(counts map[string]int) *Dictionary {
+	dict := CreateDictionary()
+	for word, count := range counts {
+		dict.PutWithCount(word, count)
+	}
+	return dict
+}
+
+func checkDictionary(t *testing.T, operation string, dict *Dictionary, expected map[string]int) {
+	wordCount := 0
+	for word, count := range expected {
+		wordCount += count
+		if information := dict.Get(word); information == nil || information.Count != count {
+			t.Errorf("%v: expected '%v' to have a count of %v, found %v", operation, word, count, information)
+		}
+	}
+
+	found := 0
+	dict.Root.forEachWord("", func(word string, information *WordInformation) {
+		found++
+		if _, ok := expected[word]; !ok {
+			t.Errorf("%v: unexpected word '%v'", operation, word)
+		}
+	})
+
+	if dict.WordCount != wordCount || dict.UniqueWordCount != len(expected) || found != len(expected) {
+		t.Errorf("%v: expected %v words and %v unique words, found %v and %v", operation, wordCount, len(expected), dict.WordCount, dict.UniqueWordCount)
+	}
+}
+
+func TestMerge(t *testing.T) {
+
+	dict := createCountedDictionary(map[string]int{"rabbit": 3, "rab": 1, "alice": 2})
+	other := createCountedDictionary(map[string]int{"rabbit": 2, "rabbits": 1, "queen": 4, "": 1})
+
+	dict.Merge(other)
+
+	checkDictionary(t, "Merge", dict, map[string]int{"rabbit": 5, "rab": 1, "alice": 2, "rabbits": 1, "queen": 4, "": 1})
+	checkDictionary(t, "Merge (other)", other, map[string]int{"rabbit": 2, "rabbits": 1, "queen": 4, "": 1})
+
+	// The dictionaries must not share their nodes
+	other.Put("queen")
+	if dict.Get("queen").Count != 4 {
+		t.Error("Expected the merged dictionary to be independent from the other one")
+	}
+}
+
+func TestSubtract(t *testing.T) {
+
+	dict := createCountedDictionary(map[string]int{"rabbit": 3, "rabbits": 1, "alice": 2, "queen": 1})
+	other := createCountedDictionary(map[string]int{"rabbit": 1, "rabbits": 1, "queen": 5, "hatter": 1})
+
+	dict.Subtract(other)
+
+	checkDictionary(t, "Subtract", dict, map[string]int{"rabbit": 2, "alice": 2})
+	if dict.Root.children['q'] != nil {
+		t.Error("Expected the empty branches to be removed")
+	}
+	if len(dict.SearchAll("rabbits", 0)) != 0 {
+		t.Error("Expected 'rabbits' to not be found anymore")
+	}
+}
+
+func TestIntersect(t *testing.T) {
+
+	dict := createCountedDictionary(map[string]int{"rabbit": 3, "rabbits": 1, "alice": 2, "queen": 1})
+	other := createCountedDictionary(map[string]int{"rabbit": 1, "rabbits": 4, "queens": 5})
+
+	dict.Intersect(other)
+
+	checkDictionary(t, "Intersect", dict, map[string]int{"rabbit": 1, "rabbits": 1})
+	if dict.Root.children['a'] != nil || dict.Root.children['q'] != nil {
+		t.Error("Expected the empty branches to be removed")
+	}
+}
+
+func TestDiff(t *testing.T) {
+
+	dict := createCountedDictionary(map[string]int{"rabbit": 3, "rabbits": 1, "alice": 2, "queen": 1})
+	other := createCountedDictionary(map[string]int{"rabbit": 1, "rabbits": 1, "queens": 5, "hatter": 2, "alice": 2})
+
+	diff := dict.Diff(other)
+
+	if len(diff.Added) != 2 || diff.Added[0] != (WordChange{"hatter", 0, 2}) || diff.Added[1] != (WordChange{"queens", 0, 5}) {
+		t.Errorf("Unexpected added words: %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != (WordChange{"queen", 1, 0}) {
+		t.Errorf("Unexpected removed words: %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0] != (WordChange{"rabbit", 3, 1}) {
+		t.Errorf("Unexpected changed words: %v", diff.Changed)
+	}
+
+	diff = dict.Diff(dict)
+	if len(diff.Added)+len(diff.Removed)+len(diff.Changed) != 0 {
+		t.Error("Expected no difference between a dictionary and itself")
+	}
+}
+
+func TestSetOperationsUpdatePhoneticIndex(t *testing.T) {
+
+	dict := CreatePhoneticDictionary(CreateSoundexEncoder())
+	dict.Put("robert")
+	other := CreateDictionary()
+	other.Put("rupert")
+
+	dict.Merge(other)
+	if dict.SearchPhonetic("robert", 0)["rupert"] == nil {
+		t.Error("Expected the merged words to be indexed")
+	}
+
+	dict.Subtract(other)
+	if dict.SearchPhonetic("robert", 0)["rupert"] != nil {
+		t.Error("Expected the subtracted words to be removed from the index")
+	}
+}
@@ -0,0 +1,153 @@
+package levenshteinsearch
+
+// jaroWinklerPrefixScale is the weight given by Winkler to each rune of the common prefix
+const jaroWinklerPrefixScale = 0.1
+
+// jaroWinklerPrefixMax is the maximum length of the common prefix taken into account by Winkler
+const jaroWinklerPrefixMax = 4
+
+// jaroWinklerBoostThreshold is the Jaro similarity from which the common prefix is taken into account
+const jaroWinklerBoostThreshold = 0.7
+
+// JaroSimilarity returns the Jaro similarity of two strings, from 0 for strings having nothing in common to
+// 1 for identical strings
+func JaroSimilarity(first string, second string) float64 {
+	firstRunes := []rune(first)
+	secondRunes := []rune(second)
+
+	if len(firstRunes) == 0 && len(secondRunes) == 0 {
+		return 1
+	}
+	if len(firstRunes) == 0 || len(secondRunes) == 0 {
+		return 0
+	}
+
+	// Runes are matching if they are identical and not farther than the window
+	window := max(max(len(firstRunes), len(secondRunes))/2-1, 0)
+	firstMatched := make([]bool, len(firstRunes))
+	secondMatched := make([]bool, len(secondRunes))
+	matches := 0
+	for i, r := range firstRunes {
+		for j := max(i-window, 0); j <= i+window && j < len(secondRunes); j++ {
+			if !secondMatched[j] && secondRunes[j] == r {
+				firstMatched[i] = true
+				secondMatched[j] = true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// Count the matching runes that are not in the same order
+	transpositions := 0
+	j := 0
+	for i, r := range firstRunes {
+		if !firstMatched[i] {
+			continue
+		}
+		for !secondMatched[j] {
+			j++
+		}
+		if r != secondRunes[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(firstRunes)) + m/float64(len(secondRunes)) + (m-float64(transpositions/2))/m) / 3
+}
+
+// JaroWinklerSimilarity returns the Jaro-Winkler similarity of two strings, which is the Jaro similarity
+// raised for the strings sharing a common prefix of up to 4 runes
+func JaroWinklerSimilarity(first string, second string) float64 {
+	similarity := JaroSimilarity(first, second)
+	if similarity <= jaroWinklerBoostThreshold {
+		return similarity
+	}
+
+	prefixLength := min(CommonPrefixLength(first, second), jaroWinklerPrefixMax)
+	return similarity + float64(prefixLength)*jaroWinklerPrefixScale*(1-similarity)
+}
+
+// CommonPrefixLength returns the number of runes at the beginning of both strings
+func CommonPrefixLength(first string, second string) int {
+	firstRunes := []rune(first)
+	secondRunes := []rune(second)
+
+	length := 0
+	for length < len(firstRunes) && length < len(secondRunes) && firstRunes[length] == secondRunes[length] {
+		length++
+	}
+	return length
+}
+
+// BigramDiceSimilarity returns the Sørensen-Dice coefficient of the bigrams of the two strings: twice the
+// number of common bigrams divided by the total number of bigrams. The strings too short to have a bigram
+// are only similar to themselves.
+func BigramDiceSimilarity(first string, second string) float64 {
+	firstBigrams := getBigrams(first)
+	secondBigrams := getBigrams(second)
+	if len(firstBigrams) == 0 || len(secondBigrams) == 0 {
+		return compareShortStrings(first, second)
+	}
+
+	common := countCommonBigrams(firstBigrams, secondBigrams)
+	return 2 * float64(common) / float64(len(firstBigrams)+len(secondBigrams))
+}
+
+// BigramJaccardSimilarity returns the Jaccard index of the bigrams of the two strings: the number of common
+// bigrams divided by the number of distinct bigrams of both strings. The strings too short to have a bigram
+// are only similar to themselves.
+func BigramJaccardSimilarity(first string, second string) float64 {
+	firstBigrams := getBigrams(first)
+	secondBigrams := getBigrams(second)
+	if len(firstBigrams) == 0 || len(secondBigrams) == 0 {
+		return compareShortStrings(first, second)
+	}
+
+	common := countCommonBigrams(firstBigrams, secondBigrams)
+	return float64(common) / float64(len(firstBigrams)+len(secondBigrams)-common)
+}
+
+// bigram is a pair of consecutive runes
+type bigram [2]rune
+
+// getBigrams returns the bigrams of a string. A bigram appearing several times is kept several times
+func getBigrams(text string) []bigram {
+	runes := []rune(text)
+	bigrams := make([]bigram, 0, max(len(runes)-1, 0))
+	for i := 1; i < len(runes); i++ {
+		bigrams = append(bigrams, bigram{runes[i-1], runes[i]})
+	}
+	return bigrams
+}
+
+// countCommonBigrams returns the number of bigrams common to both lists, each bigram being counted as many
+// times as it appears in both lists
+func countCommonBigrams(firstBigrams []bigram, secondBigrams []bigram) int {
+	remaining := make(map[bigram]int, len(secondBigrams))
+	for _, b := range secondBigrams {
+		remaining[b]++
+	}
+
+	common := 0
+	for _, b := range firstBigrams {
+		if remaining[b] > 0 {
+			remaining[b]--
+			common++
+		}
+	}
+	return common
+}
+
+// compareShortStrings gives the similarity of strings when one has no bigram
+func compareShortStrings(first string, second string) float64 {
+	if first == second {
+		return 1
+	}
+	return 0
+}
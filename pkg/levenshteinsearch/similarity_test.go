@@ -0,0 +1,56 @@
+package levenshteinsearch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJaroWinklerSimilarity(t *testing.T) {
+
+	testCases := []struct {
+		first       string
+		second      string
+		jaro        float64
+		jaroWinkler float64
+	}{
+		{"MARTHA", "MARHTA", 0.9444, 0.9611},
+		{"DWAYNE", "DUANE", 0.8222, 0.8400},
+		{"DIXON", "DICKSONX", 0.7667, 0.8133},
+		{"abc", "xyz", 0, 0},
+		{"", "", 1, 1},
+		{"", "abc", 0, 0},
+		{"rabbit", "rabbit", 1, 1},
+	}
+
+	for _, testCase := range testCases {
+		if jaro := JaroSimilarity(testCase.first, testCase.second); math.Abs(jaro-testCase.jaro) > 0.0001 {
+			t.Errorf("Expected a Jaro similarity of %v between '%v' and '%v', found %v", testCase.jaro, testCase.first, testCase.second, jaro)
+		}
+		if jaroWinkler := JaroWinklerSimilarity(testCase.first, testCase.second); math.Abs(jaroWinkler-testCase.jaroWinkler) > 0.0001 {
+			t.Errorf("Expected a Jaro-Winkler similarity of %v between '%v' and '%v', found %v", testCase.jaroWinkler, testCase.first, testCase.second, jaroWinkler)
+		}
+	}
+}
+
+func TestBigramSimilarities(t *testing.T) {
+
+	if dice := BigramDiceSimilarity("night", "nacht"); dice != 0.25 {
+		t.Errorf("Expected a Dice similarity of 0.25, found %v", dice)
+	}
+	if jaccard := BigramJaccardSimilarity("night", "nacht"); math.Abs(jaccard-1.0/7) > 0.0001 {
+		t.Errorf("Expected a Jaccard similarity of 1/7, found %v", jaccard)
+	}
+	if BigramDiceSimilarity("aaaa", "aa") != 0.5 {
+		t.Error("Expected the repeated bigrams to only be counted as many times as they appear in both strings")
+	}
+	if BigramDiceSimilarity("a", "a") != 1 || BigramJaccardSimilarity("a", "b") != 0 {
+		t.Error("Expected the strings without bigram to only be similar to themselves")
+	}
+}
+
+func TestCommonPrefixLength(t *testing.T) {
+
+	if CommonPrefixLength("rabbit", "rabid") != 3 || CommonPrefixLength("été", "étage") != 2 || CommonPrefixLength("", "a") != 0 {
+		t.Error("Unexpected common prefix length")
+	}
+}
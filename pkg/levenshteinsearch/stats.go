@@ -0,0 +1,125 @@
+package levenshteinsearch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// DictionaryStats describes the shape and the size of the trie of a dictionary
+type DictionaryStats struct {
+	// WordCount is the number of words put in the dictionary
+	WordCount int
+	// UniqueWordCount is the number of different words of the dictionary
+	UniqueWordCount int
+	// NodeCount is the number of nodes of the trie, including the root
+	NodeCount int
+	// MaxDepth is the length in runes of the longest word
+	MaxDepth int
+	// AverageDepth is the average length in runes of the different words
+	AverageDepth float64
+	// ChildrenHistogram gives for each number of children the number of nodes having it
+	ChildrenHistogram map[int]int
+	// DistinctRunes is the number of different runes used by the words
+	DistinctRunes int
+	// EstimatedBytes is an approximation of the memory used by the trie. Only the trie is taken into account,
+	// not the optional indexes. The maps of the children are estimated from their layout since Go 1.24
+	EstimatedBytes int64
+}
+
+// The layout of a map of children, used to estimate its memory. The entries are stored in groups of slots
+// preceded by a control word, the rune key being padded to the size of the pointer value. A map above a single
+// group has tables of at most 1024 slots, filled to 7/8 before growing.
+const (
+	mapHeaderBytes = 48
+	mapTableBytes  = 40
+	mapGroupSlots  = 8
+	mapGroupBytes  = 8 + mapGroupSlots*(4+4+8)
+	mapTableSlots  = 1024
+)
+
+// Stats walks the trie and returns statistics about its shape and its size
+func (dictionary *Dictionary) Stats() DictionaryStats {
+	// The sorted children are part of the memory used
+	dictionary.updateCounts()
+	stats := DictionaryStats{
+		WordCount:         dictionary.WordCount,
+		UniqueWordCount:   dictionary.UniqueWordCount,
+		ChildrenHistogram: make(map[int]int),
+	}
+
+	runes := make(map[rune]bool)
+	totalDepth := 0
+	dictionary.Root.collectStats(0, &stats, runes, &totalDepth)
+
+	stats.DistinctRunes = len(runes)
+	if stats.UniqueWordCount > 0 {
+		stats.AverageDepth = float64(totalDepth) / float64(stats.UniqueWordCount)
+	}
+
+	return stats
+}
+
+// collectStats is the recursive part of Stats
+func (trie *RuneTrie) collectStats(depth int, stats *DictionaryStats, runes map[rune]bool, totalDepth *int) {
+	stats.NodeCount++
+	stats.ChildrenHistogram[len(trie.children)]++
+	stats.EstimatedBytes += int64(unsafe.Sizeof(*trie)) + estimateMapBytes(len(trie.children))
+	stats.EstimatedBytes += int64(cap(trie.characters))*int64(unsafe.Sizeof(rune(0))) + int64(cap(trie.wordsBefore))*int64(unsafe.Sizeof(0))
+
+	if trie.information != nil {
+		stats.MaxDepth = max(stats.MaxDepth, depth)
+		*totalDepth += depth
+		stats.EstimatedBytes += int64(unsafe.Sizeof(*trie.information))
+	}
+
+	for character, child := range trie.children {
+		runes[character] = true
+		child.collectStats(depth+1, stats, runes, totalDepth)
+	}
+}
+
+// estimateMapBytes estimates the memory used by a map of children holding the given number of entries. The
+// estimation is approximate: it ignores the rounding of the allocations and the slots left by deletions
+func estimateMapBytes(entries int) int64 {
+	if entries == 0 {
+		return mapHeaderBytes
+	}
+	if entries <= mapGroupSlots {
+		return mapHeaderBytes + mapGroupBytes
+	}
+
+	slots := 2 * mapGroupSlots
+	for entries*8 > slots*7 {
+		slots *= 2
+	}
+	tables := (slots + mapTableSlots - 1) / mapTableSlots
+	return int64(mapHeaderBytes + tables*mapTableBytes + slots/mapGroupSlots*mapGroupBytes)
+}
+
+// String returns a printable report of the statistics
+func (stats DictionaryStats) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Words: %v\n", stats.WordCount)
+	fmt.Fprintf(&builder, "Unique words: %v\n", stats.UniqueWordCount)
+	fmt.Fprintf(&builder, "Nodes: %v\n", stats.NodeCount)
+	fmt.Fprintf(&builder, "Maximum depth: %v\n", stats.MaxDepth)
+	fmt.Fprintf(&builder, "Average depth: %.2f\n", stats.AverageDepth)
+	fmt.Fprintf(&builder, "Distinct runes: %v\n", stats.DistinctRunes)
+	fmt.Fprintf(&builder, "Estimated bytes: %v\n", stats.EstimatedBytes)
+
+	childrenCounts := make([]int, 0, len(stats.ChildrenHistogram))
+	for children := range stats.ChildrenHistogram {
+		childrenCounts = append(childrenCounts, children)
+	}
+	sort.Ints(childrenCounts)
+
+	builder.WriteString("Children per node:\n")
+	for _, children := range childrenCounts {
+		fmt.Fprintf(&builder, "\t%v: %v\n", children, stats.ChildrenHistogram[children])
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,91 @@
+package levenshteinsearch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("rabbit")
+	dict.Put("rabbits")
+	dict.Put("rabbit")
+	dict.Put("rat")
+	dict.Put("été")
+
+	stats := dict.Stats()
+
+	if stats.WordCount != 5 || stats.UniqueWordCount != 4 {
+		t.Errorf("Unexpected counts: %v and %v", stats.WordCount, stats.UniqueWordCount)
+	}
+	// root, r, a, b, b, i, t, s, t (rat), é, t, é
+	if stats.NodeCount != 12 {
+		t.Errorf("Expected 12 nodes, found %v", stats.NodeCount)
+	}
+	if stats.MaxDepth != 7 || stats.AverageDepth != 4.75 {
+		t.Errorf("Expected a maximum depth of 7 and an average of 4.75, found %v and %v", stats.MaxDepth, stats.AverageDepth)
+	}
+	if stats.DistinctRunes != 7 {
+		t.Errorf("Expected 7 distinct runes, found %v", stats.DistinctRunes)
+	}
+	if stats.ChildrenHistogram[0] != 3 || stats.ChildrenHistogram[1] != 7 || stats.ChildrenHistogram[2] != 2 {
+		t.Errorf("Unexpected histogram: %v", stats.ChildrenHistogram)
+	}
+	if stats.EstimatedBytes <= 0 {
+		t.Error("Expected a positive estimation of the memory")
+	}
+
+	report := stats.String()
+	if !strings.Contains(report, "Nodes: 12\n") || !strings.Contains(report, "\t1: 7\n") {
+		t.Errorf("Unexpected report:\n%v", report)
+	}
+}
+
+func TestStatsGrowWithTheDictionary(t *testing.T) {
+
+	dict := CreateDictionary()
+	empty := dict.Stats()
+	if empty.NodeCount != 1 || empty.MaxDepth != 0 || empty.AverageDepth != 0 || empty.DistinctRunes != 0 {
+		t.Errorf("Unexpected statistics for an empty dictionary: %v", empty)
+	}
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	alice := dict.Stats()
+	if alice.EstimatedBytes <= empty.EstimatedBytes || alice.NodeCount <= alice.UniqueWordCount {
+		t.Errorf("Unexpected statistics for Alice: %v", alice)
+	}
+}
+
+func TestStatsEstimatedBytesGrowWithTheWords(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	previous := dict.Stats()
+	for i, word := range aliceWords {
+		dict.Put(word)
+		if i%1000 != 999 {
+			continue
+		}
+
+		stats := dict.Stats()
+		if stats.UniqueWordCount > previous.UniqueWordCount && stats.EstimatedBytes <= previous.EstimatedBytes {
+			t.Errorf("Expected the estimation to grow from %v bytes for %v words, found %v bytes for %v words", previous.EstimatedBytes, previous.UniqueWordCount, stats.EstimatedBytes, stats.UniqueWordCount)
+		}
+		previous = stats
+	}
+
+	for entries := 1; entries <= 4096; entries++ {
+		if estimateMapBytes(entries) < estimateMapBytes(entries-1) {
+			t.Errorf("Expected the estimation of a map to grow with its %v entries", entries)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package levenshteinsearch
+
+// suffixIndex files the words of a dictionary under all their non empty suffixes. The suffixes are
+// themselves stored in a dictionary: a suffix found by an automaton gives the words ending with it, while
+// a prefix of a suffix found by an automaton gives the words containing it.
+type suffixIndex struct {
+	suffixes      *Dictionary
+	wordsBySuffix map[string][]string
+}
+
+// addWord files a new word under its suffixes
+func (index *suffixIndex) addWord(word string) {
+	runes := []rune(word)
+	for i := range runes {
+		suffix := string(runes[i:])
+		if index.suffixes.Put(suffix) {
+			index.wordsBySuffix[suffix] = make([]string, 0, 1)
+		}
+		index.wordsBySuffix[suffix] = append(index.wordsBySuffix[suffix], word)
+	}
+}
+
+// removeWord removes a word from its suffixes. The suffixes left without word are removed as well
+func (index *suffixIndex) removeWord(word string) {
+	runes := []rune(word)
+	for i := range runes {
+		suffix := string(runes[i:])
+		words := index.wordsBySuffix[suffix]
+		for j, candidate := range words {
+			if candidate == word {
+				words = append(words[:j], words[j+1:]...)
+				break
+			}
+		}
+		if len(words) == 0 {
+			delete(index.wordsBySuffix, suffix)
+			index.suffixes.removeWord(suffix)
+		} else {
+			index.wordsBySuffix[suffix] = words
+		}
+	}
+}
+
+// EnableSuffixIndex files all the words of the dictionary under their suffixes, so that they can be searched
+// by their end or by a portion of them. The words put afterwards are also filed. The index takes a memory
+// growing with the square of the length of the words.
+func (dictionary *Dictionary) EnableSuffixIndex() {
+	index := &suffixIndex{
+		suffixes:      CreateDictionary(),
+		wordsBySuffix: make(map[string][]string),
+	}
+
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		index.addWord(word)
+	})
+
+	dictionary.suffix = index
+}
+
+// SearchSuffix returns all the words of the dictionary ending with a suffix having a Levenshtein distance
+// lower or equal to distanceMax with the searched term. The suffix index must have been enabled, otherwise
+// no word is returned.
+func (dictionary *Dictionary) SearchSuffix(searchedTerm string, distanceMax int) map[string]*WordInformation {
+	results := map[string]*WordInformation{}
+
+	index := dictionary.suffix
+	if index == nil {
+		return results
+	}
+
+	// The empty suffix of every word matches
+	automaton := CreateAutomaton(searchedTerm, distanceMax)
+	if automaton.IsMatch(automaton.Start()) {
+		return dictionary.getAllWords()
+	}
+
+	for suffix := range index.suffixes.Search(automaton) {
+		for _, word := range index.wordsBySuffix[suffix] {
+			results[word] = dictionary.Get(word)
+		}
+	}
+
+	return results
+}
+
+// SearchInfix returns all the words of the dictionary containing a portion having a Levenshtein distance
+// lower or equal to distanceMax with the searched term. The suffix index must have been enabled, otherwise
+// no word is returned.
+func (dictionary *Dictionary) SearchInfix(searchedTerm string, distanceMax int) map[string]*WordInformation {
+	results := map[string]*WordInformation{}
+
+	index := dictionary.suffix
+	if index == nil {
+		return results
+	}
+
+	// The empty portion of every word matches
+	automaton := CreateAutomaton(searchedTerm, distanceMax)
+	if automaton.IsMatch(automaton.Start()) {
+		return dictionary.getAllWords()
+	}
+
+	// As soon as the beginning of a suffix matches, all the suffixes below it contain the portion
+	index.suffixes.Root.searchPrefixes(automaton, "", automaton.Start(), func(suffix string) {
+		for _, word := range index.wordsBySuffix[suffix] {
+			results[word] = dictionary.Get(word)
+		}
+	})
+
+	return results
+}
+
+// searchPrefixes walks the trie along with the automaton and calls visit for each word below a node matched
+// by the automaton. The automaton is no longer stepped below a matched node.
+func (trie *RuneTrie) searchPrefixes(automaton Automaton, prefix string, automatonState State, visit func(word string)) {
+	for character, child := range trie.children {
+		state := automaton.Step(automatonState, character)
+		if !automaton.CanMatch(state) {
+			continue
+		}
+
+		childWord := prefix + string(character)
+		if automaton.IsMatch(state) {
+			child.forEachWord(childWord, func(word string, information *WordInformation) {
+				visit(word)
+			})
+		} else {
+			child.searchPrefixes(automaton, childWord, state, visit)
+		}
+	}
+}
+
+// getAllWords returns all the words of the dictionary
+func (dictionary *Dictionary) getAllWords() map[string]*WordInformation {
+	results := map[string]*WordInformation{}
+	dictionary.Root.forEachWord("", func(word string, information *WordInformation) {
+		results[word] = information
+	})
+	return results
+}
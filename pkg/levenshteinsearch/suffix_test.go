@@ -0,0 +1,117 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestSearchSuffix(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("methanol")
+	dict.Put("ethanol")
+	dict.Put("benzene")
+	dict.EnableSuffixIndex()
+	dict.Put("propanol")
+
+	results := dict.SearchSuffix("anol", 0)
+	if len(results) != 3 || results["methanol"] == nil || results["ethanol"] == nil || results["propanol"] == nil {
+		t.Errorf("Unexpected words ending with anol: %v", results)
+	}
+
+	// A misspelled suffix
+	results = dict.SearchSuffix("enzen", 1)
+	if len(results) != 1 || results["benzene"] == nil {
+		t.Errorf("Unexpected words ending like enzen: %v", results)
+	}
+
+	// The whole word is a suffix
+	results = dict.SearchSuffix("benzene", 0)
+	if len(results) != 1 || results["benzene"] == nil {
+		t.Errorf("Unexpected words ending with benzene: %v", results)
+	}
+
+	if results := dict.SearchSuffix("than", 0); len(results) != 0 {
+		t.Errorf("Expected no word ending with than, found %v", results)
+	}
+}
+
+func TestSearchInfix(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.EnableSuffixIndex()
+	dict.Put("methanol")
+	dict.Put("ethanol")
+	dict.Put("benzene")
+
+	results := dict.SearchInfix("than", 0)
+	if len(results) != 2 || results["methanol"] == nil || results["ethanol"] == nil {
+		t.Errorf("Unexpected words containing than: %v", results)
+	}
+
+	results = dict.SearchInfix("nzn", 1)
+	if len(results) != 1 || results["benzene"] == nil {
+		t.Errorf("Unexpected words containing nzn: %v", results)
+	}
+
+	// A term short enough matches everything
+	if results := dict.SearchInfix("x", 1); len(results) != 3 {
+		t.Errorf("Expected all the words, found %v", results)
+	}
+
+	// The removed words are no longer found
+	other := CreateDictionary()
+	other.Put("ethanol")
+	dict.Subtract(other)
+	results = dict.SearchInfix("than", 0)
+	if len(results) != 1 || results["methanol"] == nil {
+		t.Errorf("Unexpected words containing than after the removal: %v", results)
+	}
+	if dict.suffix.wordsBySuffix["ethanol"][0] != "methanol" || dict.suffix.suffixes.UniqueWordCount != 15 {
+		t.Errorf("Unexpected suffixes after the removal: %v", dict.suffix.suffixes.UniqueWordCount)
+	}
+}
+
+func TestSearchSuffixWithoutIndex(t *testing.T) {
+
+	dict := CreateDictionary()
+	dict.Put("methanol")
+
+	if len(dict.SearchSuffix("anol", 0)) != 0 || len(dict.SearchInfix("than", 0)) != 0 {
+		t.Error("Expected no word without the suffix index")
+	}
+}
+
+func TestSearchSuffixMatchesBruteForce(t *testing.T) {
+
+	if err := ensureAlice(); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := CreateDictionary()
+	for _, word := range aliceWords {
+		dict.Put(word)
+	}
+	dict.EnableSuffixIndex()
+	dict.Prune(3)
+
+	data := dict.getAllWords()
+	for _, term := range []string{"ing", "ed", "tion", "abbit", "queen"} {
+		for distanceMax := 0; distanceMax < 3; distanceMax++ {
+			expectedSuffix := map[string]bool{}
+			expectedInfix := map[string]bool{}
+			for word := range data {
+				runes := []rune(word)
+				for i := 0; i <= len(runes); i++ {
+					if levenshtein([]rune(term), runes[i:]) <= distanceMax {
+						expectedSuffix[word] = true
+					}
+					for j := i; j <= len(runes); j++ {
+						if levenshtein([]rune(term), runes[i:j]) <= distanceMax {
+							expectedInfix[word] = true
+						}
+					}
+				}
+			}
+			compareResults(t, "Suffix", term, distanceMax, expectedSuffix, dict.SearchSuffix(term, distanceMax))
+			compareResults(t, "Infix", term, distanceMax, expectedInfix, dict.SearchInfix(term, distanceMax))
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package levenshteinsearch
+
+import (
+	"math"
+)
+
+// SubstitutionCost gives the cost of replacing a rune of the searched term by a rune of the word. The cost
+// is expected to be 0 for identical runes and at most 1, the cost of an insertion or a deletion.
+type SubstitutionCost func(termRune rune, wordRune rune) float64
+
+// unitSubstitutionCost is the cost of the regular Levenshtein distance
+func unitSubstitutionCost(termRune rune, wordRune rune) float64 {
+	if termRune == wordRune {
+		return 0
+	}
+	return 1
+}
+
+// WeightedAutomaton is a Levenshtein automaton in which the cost of a substitution depends on the runes
+// substituted. As the costs are not integers anymore, the states are not sparse but give the cost of each
+// position of the searched term.
+//
+// The automaton may also accept the replacement of a sequence of runes by another one, such as "ß" by
+// "ss". In that case, the states keep the last rows of costs, so that the replacement can be done once all
+// the runes of the word are known.
+type WeightedAutomaton struct {
+	distanceMax       float64
+	searchedTermRunes []rune
+	substitutionCost  SubstitutionCost
+	sequences         []sequenceRule
+	historyLength     int
+}
+
+// sequenceRule is the replacement of a sequence of runes of the searched term by a sequence of runes of
+// the word, for a given cost
+type sequenceRule struct {
+	termRunes []rune
+	wordRunes []rune
+	cost      float64
+}
+
+// weightedState is a state of the WeightedAutomaton. The last row gives, for each position of the searched
+// term, the minimal cost and the number of edits of non zero cost giving this minimal cost. The previous
+// rows and the last runes of the word are only kept if some sequences can be replaced.
+type weightedState struct {
+	rows   []weightedRow
+	recent []rune
+}
+
+// weightedRow holds the costs and the number of edits of each position of the searched term
+type weightedRow struct {
+	costs []float64
+	edits []int
+}
+
+// CreateWeightedAutomaton creates a new automaton, the substitutions being valued by the given function
+func CreateWeightedAutomaton(searchedTerm string, distanceMax float64, substitutionCost SubstitutionCost) *WeightedAutomaton {
+	return createWeightedAutomaton(searchedTerm, distanceMax, substitutionCost, nil)
+}
+
+// createWeightedAutomaton creates a new automaton, also accepting the replacement of the given sequences
+func createWeightedAutomaton(searchedTerm string, distanceMax float64, substitutionCost SubstitutionCost, sequences []sequenceRule) *WeightedAutomaton {
+	historyLength := 1
+	for _, sequence := range sequences {
+		historyLength = max(historyLength, len(sequence.wordRunes))
+	}
+
+	return &WeightedAutomaton{
+		distanceMax:       distanceMax,
+		searchedTermRunes: []rune(searchedTerm),
+		substitutionCost:  substitutionCost,
+		sequences:         sequences,
+		historyLength:     historyLength,
+	}
+}
+
+// GetDistanceMax returns the maximum cost defined for this automaton
+func (automaton *WeightedAutomaton) GetDistanceMax() float64 {
+	return automaton.distanceMax
+}
+
+// Start gives the initial state allowing to step into the automaton
+func (automaton *WeightedAutomaton) Start() State {
+	row := automaton.newRow()
+	for i := range row.costs {
+		row.costs[i] = float64(i)
+		row.edits[i] = i
+	}
+	automaton.prune(row)
+
+	return weightedState{
+		rows:   []weightedRow{row},
+		recent: []rune{},
+	}
+}
+
+// Step steps through the automaton by generating the next state based on the current one + the given
+// char.
+func (automaton *WeightedAutomaton) Step(genericState State, character rune) State {
+	state := genericState.(weightedState)
+	previous := state.rows[len(state.rows)-1]
+	row := automaton.newRow()
+
+	// Keep the last runes of the word, including the new one
+	recent := append(append(make([]rune, 0, len(state.recent)+1), state.recent...), character)
+
+	// Insertion of the character in the word
+	row.costs[0] = previous.costs[0] + 1
+	row.edits[0] = previous.edits[0] + 1
+
+	for i := 1; i < len(row.costs); i++ {
+		// Insertion of the character in the word
+		cost, edits := previous.costs[i]+1, previous.edits[i]+1
+
+		// Deletion of the rune of the searched term
+		cost, edits = bestCost(cost, edits, row.costs[i-1]+1, row.edits[i-1]+1)
+
+		// Substitution of the rune of the searched term by the character
+		substitution := automaton.substitutionCost(automaton.searchedTermRunes[i-1], character)
+		cost, edits = bestCost(cost, edits, previous.costs[i-1]+substitution, previous.edits[i-1]+countEdit(substitution))
+
+		// Replacement of a sequence of the searched term ending at i by a sequence of the word ending with
+		// the character
+		for _, sequence := range automaton.sequences {
+			if endsWith(automaton.searchedTermRunes[:i], sequence.termRunes) && endsWith(recent, sequence.wordRunes) {
+				origin := state.rows[len(state.rows)-len(sequence.wordRunes)]
+				position := i - len(sequence.termRunes)
+				cost, edits = bestCost(cost, edits, origin.costs[position]+sequence.cost, origin.edits[position]+countEdit(sequence.cost))
+			}
+		}
+
+		row.costs[i] = cost
+		row.edits[i] = edits
+	}
+
+	automaton.prune(row)
+
+	// Only keep the history needed by the sequences
+	kept := min(len(state.rows), automaton.historyLength-1)
+	rows := make([]weightedRow, 0, kept+1)
+	rows = append(rows, state.rows[len(state.rows)-kept:]...)
+	rows = append(rows, row)
+
+	return weightedState{
+		rows:   rows,
+		recent: recent[len(recent)-min(len(recent), automaton.historyLength-1):],
+	}
+}
+
+// IsMatch returns true if the given states is matching
+func (automaton *WeightedAutomaton) IsMatch(genericState State) bool {
+	cost, _ := automaton.getCost(genericState)
+	return cost <= automaton.distanceMax
+}
+
+// CanMatch returns true if the given states can match
+func (automaton *WeightedAutomaton) CanMatch(genericState State) bool {
+	state := genericState.(weightedState)
+
+	// A sequence may start from a previous row
+	for _, row := range state.rows {
+		for _, cost := range row.costs {
+			if cost <= automaton.distanceMax {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getCost returns the cost and the number of edits of a matching state
+func (automaton *WeightedAutomaton) getCost(genericState State) (float64, int) {
+	state := genericState.(weightedState)
+	row := state.rows[len(state.rows)-1]
+	return row.costs[len(row.costs)-1], row.edits[len(row.edits)-1]
+}
+
+// newRow allocates an empty row
+func (automaton *WeightedAutomaton) newRow() weightedRow {
+	return weightedRow{
+		costs: make([]float64, len(automaton.searchedTermRunes)+1),
+		edits: make([]int, len(automaton.searchedTermRunes)+1),
+	}
+}
+
+// prune sets the costs exceeding the maximum as infinite, so that they can not be reduced by the following
+// steps
+func (automaton *WeightedAutomaton) prune(row weightedRow) {
+	for i, cost := range row.costs {
+		if cost > automaton.distanceMax {
+			row.costs[i] = math.Inf(1)
+		}
+	}
+}
+
+// bestCost returns the best of two costs, the lowest number of edits breaking the ties
+func bestCost(cost float64, edits int, otherCost float64, otherEdits int) (float64, int) {
+	if otherCost < cost || (otherCost == cost && otherEdits < edits) {
+		return otherCost, otherEdits
+	}
+	return cost, edits
+}
+
+// countEdit returns the number of edits of an operation: 0 if it is free, 1 otherwise
+func countEdit(cost float64) int {
+	if cost == 0 {
+		return 0
+	}
+	return 1
+}
+
+// endsWith returns true if the runes end with the given suffix
+func endsWith(runes []rune, suffix []rune) bool {
+	if len(suffix) > len(runes) {
+		return false
+	}
+	offset := len(runes) - len(suffix)
+	for i, r := range suffix {
+		if runes[offset+i] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package levenshteinsearch
+
+import "testing"
+
+func TestWeightedAutomatonMatchesDistanceMatrix(t *testing.T) {
+
+	layout := CreateQwertyLayout()
+	words := []string{"", "a", "s", "banana", "bamama", "vanana", "cabana", "rabbit", "rsbbit"}
+
+	for _, term := range words {
+		for _, word := range words {
+			termRunes := []rune(term)
+			wordRunes := []rune(word)
+			distances := computeDistanceMatrix(termRunes, wordRunes, layout.GetSubstitutionCost, nil, false)
+			expected := distances[len(termRunes)][len(wordRunes)]
+
+			for _, distanceMax := range []float64{0, 0.5, 1, 2.5} {
+				automaton := CreateWeightedAutomaton(term, distanceMax, layout.GetSubstitutionCost)
+				state := automaton.Start()
+				for _, c := range word {
+					state = automaton.Step(state, c)
+				}
+				if automaton.IsMatch(state) != (expected <= distanceMax) {
+					t.Errorf("Unexpected match result for '%v' and '%v' with a maximum of %v", term, word, distanceMax)
+				}
+				if automaton.IsMatch(state) {
+					if cost, _ := automaton.getCost(state); cost != expected {
+						t.Errorf("Expected a cost of %v for '%v' and '%v', found %v", expected, term, word, cost)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWeightedAutomatonUnitCost(t *testing.T) {
+
+	automaton := CreateWeightedAutomaton("banana", 1, unitSubstitutionCost)
+
+	state := automaton.Start()
+	for _, c := range "bandana" {
+		state = automaton.Step(state, c)
+	}
+	if !automaton.IsMatch(state) {
+		t.Error("Expected 'bandana' to match 'banana' with a cost of 1")
+	}
+	if cost, edits := automaton.getCost(state); cost != 1 || edits != 1 {
+		t.Errorf("Expected a single edit of cost 1, found %v edits for %v", edits, cost)
+	}
+
+	state = automaton.Start()
+	for _, c := range "cabana" {
+		state = automaton.Step(state, c)
+	}
+	if automaton.CanMatch(state) {
+		t.Error("Expected 'cabana' to not match 'banana' with a cost of 1")
+	}
+}